@@ -0,0 +1,82 @@
+package unilog
+
+// sink is an Adapter of a fanoutAdapter, with an optional minimum level.
+type sink struct {
+	adapter  Adapter
+	level    Level
+	hasLevel bool
+}
+
+// enabled returns true if the sink emits entries at a specified level.
+func (s sink) enabled(level Level) bool {
+	if s.hasLevel && !level.enabledAt(s.level) {
+		return false
+	}
+	return adapterEnabled(s.adapter, level)
+}
+
+// fanoutAdapter is a `FieldAdapter` emitting entries to each of a number
+// of sinks (see `Open()`).
+type fanoutAdapter struct {
+	sinks []sink
+}
+
+// Emit emits an entry to each sink enabled at the level of the entry.
+func (a *fanoutAdapter) Emit(level Level, s string) {
+	for _, sink := range a.sinks {
+		if sink.enabled(level) {
+			sink.adapter.Emit(level, s)
+		}
+	}
+}
+
+// EmitFields emits an entry with specified fields to each sink enabled at
+// the level of the entry.
+func (a *fanoutAdapter) EmitFields(level Level, s string, fields []Field) {
+	for _, sink := range a.sinks {
+		if sink.enabled(level) {
+			emitFields(sink.adapter, level, s, fields)
+		}
+	}
+}
+
+// Enabled implements `LevelEnabler`, returning true if any sink is enabled
+// at a specified level.
+func (a *fanoutAdapter) Enabled(level Level) bool {
+	for _, sink := range a.sinks {
+		if sink.enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes any sink that implements `io.Closer`.
+func (a *fanoutAdapter) Close() error {
+	for _, sink := range a.sinks {
+		closeAdapter(sink.adapter)
+	}
+	return nil
+}
+
+// NewEntry returns a new fanoutAdapter with a new entry of each sink.
+func (a *fanoutAdapter) NewEntry() Adapter {
+	return a.each(func(adapter Adapter) Adapter { return adapter.NewEntry() })
+}
+
+// WithField returns a new fanoutAdapter with a specified field added to
+// each sink.
+func (a *fanoutAdapter) WithField(name string, value any) Adapter {
+	return a.each(func(adapter Adapter) Adapter { return adapter.WithField(name, value) })
+}
+
+// each returns a new fanoutAdapter with the adapter of each sink replaced by
+// the result of a specified func.
+func (a *fanoutAdapter) each(fn func(Adapter) Adapter) *fanoutAdapter {
+	sinks := make([]sink, len(a.sinks))
+	for i, s := range a.sinks {
+		s.adapter = fn(s.adapter)
+		sinks[i] = s
+	}
+	return &fanoutAdapter{sinks: sinks}
+}
@@ -0,0 +1,81 @@
+package unilog
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFanoutAdapter(t *testing.T) {
+	// ARRANGE
+	all := newRecordingAdapter()
+	warn := newRecordingAdapter()
+	fields := &fieldAdapter{level: Error}
+	sut := UsingAdapter(context.Background(), &fanoutAdapter{sinks: []sink{
+		{adapter: all},
+		{adapter: warn, level: Warn, hasLevel: true},
+		{adapter: fields},
+	}})
+
+	// ACT
+	entry := sut.NewEntry().WithField("id", 42)
+	entry.Info("info")
+	entry.Error("error")
+
+	// ASSERT
+	t.Run("unfiltered sink", func(t *testing.T) {
+		wanted := []recordedEntry{
+			{Info, "info", map[string]any{"id": 42}},
+			{Error, "error", map[string]any{"id": 42}},
+		}
+		got := *all.entries
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+
+	t.Run("sink with level", func(t *testing.T) {
+		wanted := []recordedEntry{{Error, "error", map[string]any{"id": 42}}}
+		got := *warn.entries
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+
+	t.Run("field adapter sink", func(t *testing.T) {
+		wanted := 1
+		got := fields.calls
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+}
+
+func TestFanoutAdapterEnabled(t *testing.T) {
+	// ARRANGE
+	sut := &fanoutAdapter{sinks: []sink{
+		{adapter: &fieldAdapter{level: Error}},
+		{adapter: newRecordingAdapter(), level: Warn, hasLevel: true},
+	}}
+
+	testcases := []struct {
+		level  Level
+		result bool
+	}{
+		{level: Error, result: true},
+		{level: Warn, result: true},
+		{level: Info, result: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			// ACT
+			got := sut.Enabled(tc.level)
+
+			// ASSERT
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ type nulAdapter struct{}
 func noop() {}
 
 func (*nulAdapter) Emit(Level, string)                { noop() }
+func (*nulAdapter) Enabled(Level) bool                { return false }
 func (nul *nulAdapter) NewEntry() Adapter             { return nul }
 func (nul *nulAdapter) WithField(string, any) Adapter { return nul }
 
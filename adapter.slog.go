@@ -0,0 +1,97 @@
+//go:build go1.21
+
+package unilog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Slog returns a Logger using a specified `*slog.Logger`.  If the specified
+// logger is nil, `slog.Default()` is used.
+//
+// Groups added to an entry (using `WithGroup()`) are emitted as native
+// `slog` groups.
+func Slog(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return UsingAdapter(context.Background(), &slogAdapter{logger})
+}
+
+// slogLevel maps a `unilog.Level` to a `slog.Level`.  `slog` has no Trace,
+// Fatal or Panic level; these are mapped to levels below `slog.LevelDebug`
+// and above `slog.LevelError` respectively.  Custom levels are mapped to the
+// `slog.Level` of the nearest built-in level at least as severe.
+var slogLevel = NewSeverityMap(map[Level]slog.Level{
+	Trace: slog.LevelDebug - 4,
+	Debug: slog.LevelDebug,
+	Info:  slog.LevelInfo,
+	Warn:  slog.LevelWarn,
+	Error: slog.LevelError,
+	Fatal: slog.LevelError + 4,
+	Panic: slog.LevelError + 8,
+})
+
+// slogAdapter is an Adapter emitting entries using a `*slog.Logger`.
+type slogAdapter struct {
+	logger *slog.Logger
+}
+
+// Emit emits an entry with no additional fields.
+func (a *slogAdapter) Emit(level Level, s string) {
+	a.EmitFields(level, s, nil)
+}
+
+// EmitFields emits an entry with specified fields, mapping any `Group`
+// fields to `slog` groups.
+func (a *slogAdapter) EmitFields(level Level, s string, fields []Field) {
+	a.logger.LogAttrs(context.Background(), slogLevel.Severity(level), s, slogAttrs(fields)...)
+}
+
+// Enabled returns true if the `slog` logger is enabled at the level
+// corresponding to a specified level.
+func (a *slogAdapter) Enabled(level Level) bool {
+	return a.logger.Enabled(context.Background(), slogLevel.Severity(level))
+}
+
+// NewEntry returns the receiver; a `slog.Logger` is immutable.
+func (a *slogAdapter) NewEntry() Adapter {
+	return a
+}
+
+// WithField returns a new adapter with a `slog` logger with the named
+// value added.
+func (a *slogAdapter) WithField(name string, value any) Adapter {
+	return &slogAdapter{a.logger.With(name, value)}
+}
+
+// slogAttrs returns the `slog.Attr` corresponding to each of a slice of
+// Fields.
+func slogAttrs(fields []Field) []slog.Attr {
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slogAttr(f)
+	}
+	return attrs
+}
+
+// slogAttr returns the `slog.Attr` corresponding to a Field.
+func slogAttr(f Field) slog.Attr {
+	switch f.Kind {
+	case StringKind:
+		return slog.String(f.Key, f.AsString())
+	case Int64Kind:
+		return slog.Int64(f.Key, f.AsInt64())
+	case BoolKind:
+		return slog.Bool(f.Key, f.AsBool())
+	case DurationKind:
+		return slog.Duration(f.Key, f.AsDuration())
+	case TimeKind:
+		return slog.Time(f.Key, f.AsTime())
+	case GroupKind:
+		return slog.Attr{Key: f.Key, Value: slog.GroupValue(slogAttrs(f.AsGroup())...)}
+	default:
+		return slog.Any(f.Key, f.Value())
+	}
+}
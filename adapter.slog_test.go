@@ -0,0 +1,56 @@
+//go:build go1.21
+
+package unilog
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogAdapter(t *testing.T) {
+	// ARRANGE
+	buf := &bytes.Buffer{}
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	sut := Slog(slog.New(handler))
+
+	testcases := []struct {
+		name   string
+		fn     func(Logger)
+		output string
+	}{
+		{name: "info", fn: func(log Logger) { log.NewEntry().Info("entry text") }, output: `{"level":"INFO","msg":"entry text"}` + "\n"},
+		{name: "trace (disabled)", fn: func(log Logger) { log.NewEntry().Trace("entry text") }, output: ""},
+		{name: "fatal", fn: func(log Logger) { log.WithExitBehaviour(NoExit()).NewEntry().Fatal("entry text") }, output: `{"level":"ERROR+4","msg":"entry text"}` + "\n"},
+		{name: "typed fields", fn: func(log Logger) {
+			log.NewEntry().With(String("s", "a"), Int64("i", 1), Bool("b", true)).Warn("entry text")
+		}, output: `{"level":"WARN","msg":"entry text","s":"a","i":1,"b":true}` + "\n"},
+		{name: "groups", fn: func(log Logger) {
+			log.NewEntry().WithField("id", 1).WithGroup("db").WithField("id", 2).WithGroup("conn").WithField("port", 5432).Error("entry text")
+		}, output: `{"level":"ERROR","msg":"entry text","id":1,"db":{"id":2,"conn":{"port":5432}}}` + "\n"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			defer buf.Reset()
+
+			// ACT
+			tc.fn(sut)
+
+			// ASSERT
+			wanted := tc.output
+			got := buf.String()
+			if wanted != got {
+				t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -19,38 +20,55 @@ var logPrefix = map[Level]string{
 	Warn:  "WARN",
 	Error: "ERROR",
 	Fatal: "FATAL",
+	Panic: "PANIC",
+}
+
+// levelPrefix returns the prefix for entries at a specified level; the
+// prefix of a custom level is its name, in uppercase.
+func levelPrefix(level Level) string {
+	if prefix, ok := logPrefix[level]; ok {
+		return prefix
+	}
+	return strings.ToUpper(level.String())
 }
 
 type stdlogAdapter struct {
 	fields map[string]any
 }
 
+// fieldData returns the fields of the adapter formatted as a space separated
+// list of name=value pairs, sorted by name.
 func (a *stdlogAdapter) fieldData() string {
-	keys := make([]string, 0, len(a.fields))
-	for k := range a.fields {
+	return fieldData(a.fields)
+}
+
+// fieldData formats a map of fields as a space separated list of name=value
+// pairs, sorted by name.  Names or values containing spaces are quoted.
+//
+// If there are any fields the result has a trailing space.
+func fieldData(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
 	data := ""
 	for _, k := range keys {
-		kf := "%s"
-		vf := "%s"
+		vs := fmt.Sprintf("%v", fields[k])
 		if strings.Contains(k, " ") {
-			kf = "%q"
+			k = strconv.Quote(k)
 		}
-		v := a.fields[k]
-		vs := fmt.Sprintf("%s", v)
 		if strings.Contains(vs, " ") {
-			vf = "%q"
+			vs = strconv.Quote(vs)
 		}
-		data = data + fmt.Sprintf(kf+"="+vf+" ", k, v)
+		data = data + k + "=" + vs + " "
 	}
 	return data
 }
 
 func (a *stdlogAdapter) Emit(level Level, s string) {
-	log.Printf(a.fieldData() + logPrefix[level] + ": " + s)
+	log.Printf(a.fieldData() + levelPrefix(level) + ": " + s)
 }
 
 func (log *stdlogAdapter) NewEntry() Adapter {
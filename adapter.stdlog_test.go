@@ -100,6 +100,28 @@ func TestLogAdapterWithFields(t *testing.T) {
 	}
 }
 
+func TestStdLogAdapterFieldValues(t *testing.T) {
+	// ARRANGE
+	stdlog.SetOutput(log.Sink())
+	stdlog.SetFlags(0) // clear all flags so that we can test only the output produced by LogAdapter
+	defer log.Reset()
+
+	sut := (&stdlogAdapter{}).
+		WithField("int", 5).
+		WithField("bool", true).
+		WithField("string", "da ta")
+
+	// ACT
+	sut.Emit(Info, "entry text")
+
+	// ASSERT
+	wanted := "bool=true int=5 string=\"da ta\" INFO: entry text\n"
+	got := log.String()
+	if wanted != got {
+		t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+	}
+}
+
 func TestUsingStdLog(t *testing.T) {
 	// ACT
 	ctx := context.Background()
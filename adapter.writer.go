@@ -0,0 +1,277 @@
+package unilog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// now returns the current time; replaced in tests.
+var now = time.Now
+
+// encoder writes an entry to a buffer in a specific format.
+type encoder func(buf *bytes.Buffer, t time.Time, level Level, s string, fields []Field)
+
+// JSONLog returns a Logger writing entries to a specified io.Writer as JSON
+// objects, one per line, with "time", "level" and "msg" members followed by
+// a member for each field (with any group as a nested object):
+//
+//	{"time":"2006-01-02T15:04:05.999999999Z","level":"info","msg":"started","port":8080}
+func JSONLog(w io.Writer) Logger {
+	return UsingAdapter(context.Background(), newWriterAdapter(w, encodeJSON))
+}
+
+// LogfmtLog returns a Logger writing entries to a specified io.Writer in
+// logfmt format, one per line, with "time", "level" and "msg" keys followed
+// by a key for each field (with the fields of any group qualified by the
+// group name):
+//
+//	time=2006-01-02T15:04:05.999999999Z level=info msg=started port=8080
+func LogfmtLog(w io.Writer) Logger {
+	return UsingAdapter(context.Background(), newWriterAdapter(w, encodeLogfmt))
+}
+
+// TextLog returns a Logger writing entries to a specified io.Writer in a
+// human-readable text format, one per line, with the time, level and message
+// followed by the fields of the entry in logfmt format:
+//
+//	2006-01-02T15:04:05.999999999Z INFO started port=8080
+func TextLog(w io.Writer) Logger {
+	return UsingAdapter(context.Background(), newWriterAdapter(w, encodeText))
+}
+
+// writerAdapter is a `FieldAdapter` writing entries to an io.Writer using
+// a specified encoder.
+type writerAdapter struct {
+	mu     *sync.Mutex // serialises writes; shared by all entries of the adapter
+	w      io.Writer
+	encode encoder
+	fields []Field // fields added using WithField()
+}
+
+// newWriterAdapter returns a new writerAdapter.
+func newWriterAdapter(w io.Writer, encode encoder) *writerAdapter {
+	return &writerAdapter{mu: &sync.Mutex{}, w: w, encode: encode}
+}
+
+// Emit writes an entry with any fields added using `WithField()`.
+func (a *writerAdapter) Emit(level Level, s string) {
+	a.EmitFields(level, s, nil)
+}
+
+// EmitFields writes an entry with any fields added using `WithField()` and
+// specified fields.
+func (a *writerAdapter) EmitFields(level Level, s string, fields []Field) {
+	if len(a.fields) > 0 {
+		fields = append(a.fields[:len(a.fields):len(a.fields)], fields...)
+	}
+
+	buf := &bytes.Buffer{}
+	a.encode(buf, now(), level, s, fields)
+	buf.WriteByte('\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, _ = a.w.Write(buf.Bytes())
+}
+
+// Close closes the io.Writer of the adapter, if it implements io.Closer and
+// is not stdout or stderr.
+func (a *writerAdapter) Close() error {
+	if a.w == os.Stdout || a.w == os.Stderr {
+		return nil
+	}
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// NewEntry returns a new adapter with the fields of the receiver.
+func (a *writerAdapter) NewEntry() Adapter {
+	entry := *a
+	return &entry
+}
+
+// WithField returns a new adapter with the fields of the receiver and a
+// specified field.
+func (a *writerAdapter) WithField(name string, value any) Adapter {
+	entry := *a
+	entry.fields = withFields(a.fields, Any(name, value))
+	return &entry
+}
+
+// closeAdapter closes an Adapter if it implements io.Closer.
+func closeAdapter(adapter Adapter) {
+	if c, ok := adapter.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+// levelName returns the name of a level for writing to an entry (lowercase).
+func levelName(level Level) string {
+	if text, err := level.MarshalText(); err == nil {
+		return string(text)
+	}
+	return level.String()
+}
+
+// encodeJSON writes an entry as a JSON object.
+func encodeJSON(buf *bytes.Buffer, t time.Time, level Level, s string, fields []Field) {
+	buf.WriteString(`{"time":`)
+	appendJSONString(buf, t.Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	appendJSONString(buf, levelName(level))
+	buf.WriteString(`,"msg":`)
+	appendJSONString(buf, s)
+	for _, f := range fields {
+		buf.WriteByte(',')
+		appendJSONField(buf, f)
+	}
+	buf.WriteByte('}')
+}
+
+// appendJSONField writes a field as a JSON object member.
+func appendJSONField(buf *bytes.Buffer, f Field) {
+	appendJSONString(buf, f.Key)
+	buf.WriteByte(':')
+
+	switch f.Kind {
+	case StringKind:
+		appendJSONString(buf, f.AsString())
+	case Int64Kind:
+		buf.WriteString(strconv.FormatInt(f.AsInt64(), 10))
+	case BoolKind:
+		buf.WriteString(strconv.FormatBool(f.AsBool()))
+	case DurationKind:
+		appendJSONString(buf, f.AsDuration().String())
+	case TimeKind:
+		appendJSONString(buf, f.AsTime().Format(time.RFC3339Nano))
+	case GroupKind:
+		buf.WriteByte('{')
+		for i, m := range f.AsGroup() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			appendJSONField(buf, m)
+		}
+		buf.WriteByte('}')
+	default:
+		appendJSONValue(buf, f.Value())
+	}
+}
+
+// appendJSONValue writes a value as JSON.  An error is written as its
+// message; a value that cannot be marshalled is written as a string
+// (formatted using %v).
+func appendJSONValue(buf *bytes.Buffer, v any) {
+	if err, ok := v.(error); ok {
+		appendJSONString(buf, err.Error())
+		return
+	}
+	data, err := marshalJSON(v)
+	if err != nil {
+		appendJSONString(buf, fmt.Sprintf("%v", v))
+		return
+	}
+	buf.Write(data)
+}
+
+// appendJSONString writes a string as JSON.
+func appendJSONString(buf *bytes.Buffer, s string) {
+	data, _ := marshalJSON(s)
+	buf.Write(data)
+}
+
+// marshalJSON returns the JSON encoding of a value, without the escaping of
+// HTML characters applied by json.Marshal (e.g. '>' is not written as
+// "\u003e").
+func marshalJSON(v any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// encodeLogfmt writes an entry in logfmt format.
+func encodeLogfmt(buf *bytes.Buffer, t time.Time, level Level, s string, fields []Field) {
+	buf.WriteString("time=")
+	buf.WriteString(t.Format(time.RFC3339Nano))
+	buf.WriteString(" level=")
+	buf.WriteString(levelName(level))
+	buf.WriteString(" msg=")
+	buf.WriteString(logfmtValue(s))
+	appendLogfmtFields(buf, fields)
+}
+
+// encodeText writes an entry in text format.
+func encodeText(buf *bytes.Buffer, t time.Time, level Level, s string, fields []Field) {
+	buf.WriteString(t.Format(time.RFC3339Nano))
+	buf.WriteByte(' ')
+	buf.WriteString(levelPrefix(level))
+	buf.WriteByte(' ')
+	appendTextMessage(buf, s)
+	appendLogfmtFields(buf, fields)
+}
+
+// appendTextMessage writes a message with any control or non-printable
+// characters (including invalid UTF-8) escaped, as in a Go string literal,
+// so that a message cannot span lines or forge an entry.
+func appendTextMessage(buf *bytes.Buffer, s string) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(buf, `\x%02x`, s[i])
+		case r == ' ' || strconv.IsPrint(r):
+			buf.WriteString(s[i : i+size])
+		default:
+			q := strconv.QuoteRune(r)
+			buf.WriteString(q[1 : len(q)-1])
+		}
+		i += size
+	}
+}
+
+// appendLogfmtFields writes fields as space-separated key=value pairs,
+// with the fields of any group qualified by the group name.
+func appendLogfmtFields(buf *bytes.Buffer, fields []Field) {
+	for _, f := range Flatten(fields) {
+		buf.WriteByte(' ')
+		buf.WriteString(logfmtValue(f.Key))
+		buf.WriteByte('=')
+
+		var s string
+		switch f.Kind {
+		case StringKind:
+			s = f.AsString()
+		case TimeKind:
+			s = f.AsTime().Format(time.RFC3339Nano)
+		default:
+			s = fmt.Sprintf("%v", f.Value())
+		}
+		buf.WriteString(logfmtValue(s))
+	}
+}
+
+// logfmtValue returns a string quoted if it is empty or contains a space,
+// '=', '"' or any non-printable character.
+func logfmtValue(s string) string {
+	if s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || !strconv.IsPrint(r)
+	}) != -1 {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,115 @@
+package unilog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWriterAdapters(t *testing.T) {
+	// ARRANGE
+	og := now
+	defer func() { now = og }()
+	now = func() time.Time { return time.Date(2010, 9, 8, 7, 6, 5, 0, time.UTC) }
+
+	fields := []Field{
+		String("name", "a value"),
+		Int64("count", 3),
+		Bool("ok", true),
+		Duration("elapsed", 1500*time.Millisecond),
+		Err(errors.New("failed")),
+		Group("db", String("table", "users")),
+		Any("tags", []string{"a", "b"}),
+	}
+
+	testcases := []struct {
+		name   string
+		logger func(*bytes.Buffer) Logger
+		result string
+	}{
+		{name: "json",
+			logger: func(buf *bytes.Buffer) Logger { return JSONLog(buf) },
+			result: `{"time":"2010-09-08T07:06:05Z","level":"info","msg":"message \"quoted\"","name":"a value","count":3,"ok":true,"elapsed":"1.5s","error":"failed","db":{"table":"users"},"tags":["a","b"]}` + "\n",
+		},
+		{name: "logfmt",
+			logger: func(buf *bytes.Buffer) Logger { return LogfmtLog(buf) },
+			result: `time=2010-09-08T07:06:05Z level=info msg="message \"quoted\"" name="a value" count=3 ok=true elapsed=1.5s error=failed db.table=users tags="[a b]"` + "\n",
+		},
+		{name: "text",
+			logger: func(buf *bytes.Buffer) Logger { return TextLog(buf) },
+			result: `2010-09-08T07:06:05Z INFO message "quoted" name="a value" count=3 ok=true elapsed=1.5s error=failed db.table=users tags="[a b]"` + "\n",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			buf := &bytes.Buffer{}
+			sut := tc.logger(buf).NewEntry().With(fields...)
+
+			// ACT
+			sut.Info(`message "quoted"`)
+
+			// ASSERT
+			wanted := tc.result
+			got := buf.String()
+			if wanted != got {
+				t.Errorf("\nwanted %s\ngot    %s", wanted, got)
+			}
+		})
+	}
+}
+
+func TestWriterAdapterWithField(t *testing.T) {
+	// ARRANGE
+	og := now
+	defer func() { now = og }()
+	now = func() time.Time { return time.Date(2010, 9, 8, 7, 6, 5, 0, time.UTC) }
+
+	buf := &bytes.Buffer{}
+	sut := newWriterAdapter(buf, encodeLogfmt).WithField("id", 42)
+
+	// ACT
+	sut.NewEntry().WithField("empty", "").Emit(Warn, "message")
+
+	// ASSERT
+	wanted := `time=2010-09-08T07:06:05Z level=warn msg=message id=42 empty=""` + "\n"
+	got := buf.String()
+	if wanted != got {
+		t.Errorf("\nwanted %s\ngot    %s", wanted, got)
+	}
+}
+
+func TestTextLogEscapesMessage(t *testing.T) {
+	// ARRANGE
+	og := now
+	defer func() { now = og }()
+	now = func() time.Time { return time.Date(2010, 9, 8, 7, 6, 5, 0, time.UTC) }
+
+	testcases := []struct {
+		name   string
+		msg    string
+		result string
+	}{
+		{name: "forged entry", msg: "line1\n2006-01-02T00:00:00Z ERROR forged", result: `2010-09-08T07:06:05Z INFO line1\n2006-01-02T00:00:00Z ERROR forged` + "\n"},
+		{name: "control characters", msg: "a\tb\rc\x00d\u2028e", result: `2010-09-08T07:06:05Z INFO a\tb\rc\x00d\u2028e` + "\n"},
+		{name: "invalid utf-8", msg: "a\xffb", result: `2010-09-08T07:06:05Z INFO a\xffb` + "\n"},
+		{name: "printable", msg: `quoted "text" and ünïcode`, result: `2010-09-08T07:06:05Z INFO quoted "text" and ünïcode` + "\n"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			buf := &bytes.Buffer{}
+
+			// ACT
+			TextLog(buf).NewEntry().Info(tc.msg)
+
+			// ASSERT
+			wanted := tc.result
+			got := buf.String()
+			if wanted != got {
+				t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+			}
+		})
+	}
+}
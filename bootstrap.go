@@ -0,0 +1,217 @@
+package unilog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultBootstrapSize is the number of entries buffered by a bootstrap
+// logger if no (or an invalid) size is specified.
+const defaultBootstrapSize = 1000
+
+// bootstrapOutput is the writer to which unconfigured bootstrap entries
+// are written by `BootstrapLogger.Close()`.
+var bootstrapOutput io.Writer = os.Stderr
+
+// BootstrapLogger is a `Logger` that buffers entries until an `Adapter` is
+// configured, allowing entries emitted during initialisation (e.g. in
+// `init()` funcs) to be captured before logging has been configured.
+//
+// Entries are buffered with the time at which they were emitted, their level,
+// message and fields (including fields added by enrichment, as at the time
+// of the entry).  When an Adapter is configured (see `Configure()`) buffered
+// entries are replayed, with a "recorded" field holding the time at which
+// each entry was emitted; entries subsequently emitted are passed directly
+// to the Adapter.
+//
+// If the buffer is full, the oldest entry is discarded; a `Warn` entry
+// reporting the number of entries discarded is emitted when buffered entries
+// are replayed.
+//
+// If the process exits before an Adapter is configured the buffered entries
+// are lost, unless `Close()` is called (e.g. deferred in main()), which
+// writes any buffered entries to stderr.  Buffered entries are also written
+// to stderr before any `Fatal` entry terminates the process.
+type BootstrapLogger struct {
+	Logger
+	adapter *bootstrapAdapter
+}
+
+// NewBootstrapLogger returns a new `BootstrapLogger` buffering up to a
+// specified number of entries.  If the specified size is not greater than
+// zero a default of 1000 is used.
+func NewBootstrapLogger(size int) *BootstrapLogger {
+	if size <= 0 {
+		size = defaultBootstrapSize
+	}
+	a := &bootstrapAdapter{size: size}
+	return &BootstrapLogger{
+		Logger: UsingAdapter(context.Background(), a).
+			WithExitBehaviour(func(code int, _ string) {
+				a.close()
+				exit(code)
+			}),
+		adapter: a,
+	}
+}
+
+// Configure sets the `Adapter` to which entries are emitted, replaying any
+// buffered entries (at a level enabled by the Adapter).  Only the first call
+// to Configure (or `Use()` or `Close()`) has any effect.
+//
+// An error is returned if the Adapter emits entries to the logger (e.g. the
+// Adapter of a SwappableLogger using the logger), which would form a cycle;
+// the logger is then not configured.
+func (b *BootstrapLogger) Configure(adapter Adapter) error {
+	return b.adapter.configure(adapter)
+}
+
+// Use configures the logger to emit entries using the `Adapter` of a
+// specified Logger (e.g. `StdLog()` or a Logger returned by `FromConfig()`),
+// as for `Configure()`.  Only the Adapter is used; any other configuration of
+// the specified Logger (e.g. redaction) is not applied.
+//
+// As with `SwappableLogger.Use()`, an error is returned if the specified
+// Logger was not initialised by this package, or if it emits entries to the
+// logger, which would form a cycle; the logger is then not configured.
+func (b *BootstrapLogger) Use(log Logger) error {
+	al, ok := log.(adapterLogger)
+	if !ok {
+		return fmt.Errorf("unilog: unsupported logger: %T", log)
+	}
+	return b.adapter.configure(al.loggerAdapter())
+}
+
+// Close writes any buffered entries to stderr if no `Adapter` has been
+// configured; entries subsequently emitted are also written to stderr.
+// If an Adapter has been configured, Close has no effect.
+func (b *BootstrapLogger) Close() {
+	b.adapter.close()
+}
+
+// loggerAdapter returns the Adapter to which the logger emits entries.
+func (b *BootstrapLogger) loggerAdapter() Adapter {
+	return b.adapter
+}
+
+// bootstrapEntry is an entry buffered by a bootstrapAdapter.
+type bootstrapEntry struct {
+	time   time.Time
+	level  Level
+	s      string
+	fields []Field
+}
+
+// bootstrapAdapter is an `Adapter` buffering entries until a target Adapter
+// is configured, after which entries are emitted by the target.
+type bootstrapAdapter struct {
+	mu      sync.Mutex
+	target  atomic.Value // adapterValue; set once configured
+	size    int
+	entries []bootstrapEntry
+	dropped int
+}
+
+// configured returns the configured Adapter, or nil if none is configured.
+func (a *bootstrapAdapter) configured() Adapter {
+	target, _ := a.target.Load().(adapterValue)
+	return target.Adapter
+}
+
+// configure replays buffered entries to a specified Adapter then sets it
+// as the target of the adapter.  Entries emitted while entries are being
+// replayed are emitted after them.  If the Adapter emits entries to the
+// receiver an error is returned and the receiver is not configured.
+func (a *bootstrapAdapter) configure(adapter Adapter) error {
+	if adapter == nil {
+		adapter = &nulAdapter{}
+	}
+
+	swapMu.Lock()
+	defer swapMu.Unlock()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.configured() != nil {
+		return nil
+	}
+	if emitsTo(adapter, a) {
+		return fmt.Errorf("unilog: cycle: %T emits entries to the bootstrap logger", adapter)
+	}
+
+	if a.dropped > 0 {
+		emitFields(adapter, Warn, fmt.Sprintf("bootstrap: %d entries discarded (buffer full)", a.dropped), nil)
+	}
+	for _, e := range a.entries {
+		if adapterEnabled(adapter, e.level) {
+			emitFields(adapter, e.level, e.s, withFields(e.fields, Time("recorded", e.time)))
+		}
+	}
+	a.entries = nil
+	a.dropped = 0
+
+	a.target.Store(adapterValue{adapter})
+	return nil
+}
+
+// close configures the adapter to write entries to stderr (in the format
+// of `TextLog()`).
+func (a *bootstrapAdapter) close() {
+	_ = a.configure(newWriterAdapter(bootstrapOutput, encodeText))
+}
+
+// Emit emits an entry with no fields.
+func (a *bootstrapAdapter) Emit(level Level, s string) {
+	a.EmitFields(level, s, nil)
+}
+
+// EmitFields emits an entry with specified fields using the configured
+// Adapter, or buffers the entry if no Adapter is configured.
+func (a *bootstrapAdapter) EmitFields(level Level, s string, fields []Field) {
+	if target := a.configured(); target != nil {
+		emitFields(target, level, s, fields)
+		return
+	}
+
+	a.mu.Lock()
+	if target := a.configured(); target != nil {
+		a.mu.Unlock()
+		emitFields(target, level, s, fields)
+		return
+	}
+	defer a.mu.Unlock()
+
+	if len(a.entries) == a.size {
+		copy(a.entries, a.entries[1:])
+		a.entries = a.entries[:a.size-1]
+		a.dropped++
+	}
+	a.entries = append(a.entries, bootstrapEntry{time: time.Now(), level: level, s: s, fields: fields})
+}
+
+// Enabled returns true if the configured Adapter emits entries at a specified
+// level.  All levels are enabled until an Adapter is configured.
+func (a *bootstrapAdapter) Enabled(level Level) bool {
+	if target := a.configured(); target != nil {
+		return adapterEnabled(target, level)
+	}
+	return true
+}
+
+// NewEntry returns the receiver; fields are held by the Logger, not the
+// Adapter.
+func (a *bootstrapAdapter) NewEntry() Adapter {
+	return a
+}
+
+// WithField returns the receiver; fields are held by the Logger and passed
+// to the Adapter using `EmitFields()`.
+func (a *bootstrapAdapter) WithField(string, any) Adapter {
+	return a
+}
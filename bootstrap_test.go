@@ -0,0 +1,193 @@
+package unilog
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBootstrapLogger(t *testing.T) {
+	// ARRANGE
+	sut := NewBootstrapLogger(0)
+	entry := sut.NewEntry().WithValues("id", 42)
+	before := time.Now()
+	entry.Info("buffered")
+	entry.Trace("buffered trace")
+
+	adapter := &fieldAdapter{level: Debug}
+	recording := &recordingFieldAdapter{fieldAdapter: adapter}
+
+	// ACT
+	sut.Configure(recording)
+	entry.Warn("direct")
+	sut.Configure(newRecordingAdapter()) // no effect
+
+	// ASSERT
+	t.Run("entries", func(t *testing.T) {
+		wanted := []string{"INFO: buffered", "WARN: direct"}
+		got := recording.messages
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+		}
+	})
+
+	t.Run("replayed fields", func(t *testing.T) {
+		fields := recording.fields[0]
+		if len(fields) != 2 || fields[0] != Any("id", 42) || fields[1].Key != "recorded" {
+			t.Fatalf("unexpected fields: %#v", fields)
+		}
+		if recorded := fields[1].AsTime(); recorded.Before(before) {
+			t.Errorf("wanted recorded time after %v, got %v", before, recorded)
+		}
+	})
+
+	t.Run("direct fields", func(t *testing.T) {
+		wanted := []Field{Any("id", 42)}
+		got := recording.fields[1]
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+}
+
+func TestBootstrapLoggerUse(t *testing.T) {
+	testcases := []struct {
+		name string
+		log  func(sut *BootstrapLogger) Logger
+		err  bool
+	}{
+		{name: "logger",
+			log: func(*BootstrapLogger) Logger { return JSONLog(&bytes.Buffer{}) },
+		},
+		{name: "swappable",
+			log: func(*BootstrapLogger) Logger { return NewSwappableLogger(newRecordingAdapter()) },
+		},
+		{name: "unsupported",
+			log: func(*BootstrapLogger) Logger { return struct{ Logger }{Nul()} },
+			err: true,
+		},
+		{name: "self",
+			log: func(sut *BootstrapLogger) Logger { return sut },
+			err: true,
+		},
+		{name: "swappable using self",
+			log: func(sut *BootstrapLogger) Logger {
+				sl := NewSwappableLogger(nil)
+				_, _ = sl.Use(sut)
+				return sl
+			},
+			err: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			sut := NewBootstrapLogger(0)
+			sut.NewEntry().Info("buffered")
+
+			// ACT
+			err := sut.Use(tc.log(sut))
+
+			// ASSERT
+			if tc.err != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			wanted := !tc.err
+			got := sut.adapter.configured() != nil
+			if wanted != got {
+				t.Errorf("configured: wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestBootstrapLoggerBufferFull(t *testing.T) {
+	// ARRANGE
+	sut := NewBootstrapLogger(2)
+	entry := sut.NewEntry()
+	entry.Info("1")
+	entry.Info("2")
+	entry.Info("3")
+
+	recording := newRecordingAdapter()
+
+	// ACT
+	sut.Configure(recording)
+
+	// ASSERT
+	wanted := []string{"WARN: bootstrap: 1 entries discarded (buffer full)", "INFO: 2", "INFO: 3"}
+	got := []string{}
+	for _, e := range *recording.entries {
+		got = append(got, levelPrefix(e.level)+": "+e.s)
+	}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+	}
+}
+
+func TestBootstrapLoggerClose(t *testing.T) {
+	// ARRANGE
+	og := bootstrapOutput
+	defer func() { bootstrapOutput = og }()
+	buf := &bytes.Buffer{}
+	bootstrapOutput = buf
+
+	sut := NewBootstrapLogger(0)
+	sut.NewEntry().WithValues("id", 42).Info("buffered")
+
+	// ACT
+	sut.Close()
+	sut.NewEntry().Warn("direct")
+
+	// ASSERT
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 ||
+		!strings.Contains(lines[0], " INFO buffered id=42 recorded=") ||
+		!strings.HasSuffix(lines[1], " WARN direct") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestBootstrapLoggerFatal(t *testing.T) {
+	// ARRANGE
+	og := bootstrapOutput
+	defer func() { bootstrapOutput = og }()
+	buf := &bytes.Buffer{}
+	bootstrapOutput = buf
+
+	ofn := ExitFn
+	defer func() { ExitFn = ofn }()
+	exitCode := 0
+	ExitFn = func(code int) { exitCode = code }
+
+	sut := NewBootstrapLogger(0)
+	entry := sut.NewEntry()
+	entry.Info("buffered")
+
+	// ACT
+	entry.Fatal("fatal")
+
+	// ASSERT
+	if exitCode != 1 {
+		t.Errorf("wanted exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(buf.String(), "INFO buffered") || !strings.Contains(buf.String(), "FATAL fatal") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+// recordingFieldAdapter is a fieldAdapter recording the messages and fields
+// of all entries emitted.
+type recordingFieldAdapter struct {
+	*fieldAdapter
+	messages []string
+	fields   [][]Field
+}
+
+func (a *recordingFieldAdapter) EmitFields(level Level, s string, fields []Field) {
+	a.fieldAdapter.EmitFields(level, s, fields)
+	a.messages = append(a.messages, levelPrefix(level)+": "+s)
+	a.fields = append(a.fields, fields)
+}
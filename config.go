@@ -0,0 +1,229 @@
+package unilog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Config describes the configuration of a Logger (see `FromConfig()`).
+// A Config may be decoded from JSON or YAML, or initialised from environment
+// variables using `ConfigFromEnv()`:
+//
+//	{
+//		"level": "info",
+//		"format": "json",
+//		"output": "/var/log/app.log",
+//		"levels": {"payments": "debug", "payments.db": "warn"},
+//		"redact": [{"names": ["password", "*token*"]}]
+//	}
+type Config struct {
+	// Level is the root level (see `ParseLevel()`).  The default is "info".
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+
+	// Format is the format of entries: "json", "logfmt", "text", "stdlog"
+	// or "nul".  The default is "text".
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+
+	// Output is the destination of entries: "stdout", "stderr" or the path
+	// of a file (to which entries are appended).  The default is "stderr".
+	// Output is ignored for the "stdlog" and "nul" formats.
+	Output string `json:"output,omitempty" yaml:"output,omitempty"`
+
+	// Sinks are specifications of sinks to which entries are emitted (see
+	// `Open()`).  If specified, Format and Output are ignored.
+	Sinks []string `json:"sinks,omitempty" yaml:"sinks,omitempty"`
+
+	// Levels are the levels for named Loggers, keyed by name (see `Levels`).
+	Levels map[string]string `json:"levels,omitempty" yaml:"levels,omitempty"`
+
+	// Redact are rules for the redaction of sensitive fields (see `Redactor`).
+	Redact []RedactConfig `json:"redact,omitempty" yaml:"redact,omitempty"`
+}
+
+// RedactConfig describes a rule redacting fields with names matching any of
+// a number of patterns (see `RedactNames()`) in a Config.
+type RedactConfig struct {
+	// Names are the patterns identifying fields to be redacted.
+	Names []string `json:"names" yaml:"names"`
+
+	// Strategy is the redaction applied: "mask" (the default), "partial",
+	// "remove" or "hash" (see `Mask()`, `PartialMask()`, `Remove()` and
+	// `Hash()`).
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+
+	// Keep is the number of characters left unmasked by a "partial" redaction.
+	Keep int `json:"keep,omitempty" yaml:"keep,omitempty"`
+}
+
+// Environment variables read by `ConfigFromEnv()`.
+const (
+	EnvLevel  = "UNILOG_LEVEL"  // the root level, e.g. "debug"
+	EnvFormat = "UNILOG_FORMAT" // the format of entries, e.g. "json"
+	EnvOutput = "UNILOG_OUTPUT" // the destination of entries, e.g. "stdout"
+	EnvLevels = "UNILOG_LEVELS" // levels for named Loggers, e.g. "payments=debug,payments.db=warn"
+)
+
+// ConfigFromEnv returns a Config initialised from environment variables:
+//
+//	UNILOG_LEVEL   the root level (Config.Level)
+//	UNILOG_FORMAT  the format of entries (Config.Format)
+//	UNILOG_OUTPUT  the destination of entries (Config.Output)
+//	UNILOG_LEVELS  a comma-separated list of name=level pairs (Config.Levels)
+//
+// Values are validated by `FromConfig()`; a UNILOG_LEVELS item with no '='
+// is retained as a name with an empty (and therefore invalid) level.
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Level:  os.Getenv(EnvLevel),
+		Format: os.Getenv(EnvFormat),
+		Output: os.Getenv(EnvOutput),
+	}
+
+	if levels := os.Getenv(EnvLevels); levels != "" {
+		cfg.Levels = map[string]string{}
+		for _, item := range strings.Split(levels, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			name, level := item, ""
+			if i := strings.IndexByte(item, '='); i != -1 {
+				name, level = strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+			}
+			cfg.Levels[name] = level
+		}
+	}
+	return cfg
+}
+
+// FromConfig returns a Logger configured by a specified Config.  The level of
+// entries emitted by the Logger (and any named Logger initialised from it) is
+// determined by `Levels` with the root level and levels for names specified
+// by the Config.
+//
+// An error is returned if any value in the Config is invalid or the output
+// file (or any sink) cannot be opened.  An output file remains open for the lifetime of
+// the process.
+func FromConfig(cfg Config) (Logger, error) {
+	levels, err := cfg.levels()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := cfg.rules()
+	if err != nil {
+		return nil, err
+	}
+
+	adapter, err := cfg.adapter()
+	if err != nil {
+		return nil, err
+	}
+
+	log := UsingAdapter(context.Background(), adapter).WithLevels(levels)
+	if len(rules) > 0 {
+		log = log.WithRedactor(NewRedactor(rules...))
+	}
+	return log, nil
+}
+
+// levels returns the Levels specified by the Config.
+func (cfg Config) levels() (*Levels, error) {
+	root := Info
+	if cfg.Level != "" {
+		level, err := ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("unilog: config: level: %w", err)
+		}
+		root = level
+	}
+
+	levels := NewLevels(root)
+	for name, s := range cfg.Levels {
+		level, err := ParseLevel(s)
+		if err != nil {
+			return nil, fmt.Errorf("unilog: config: levels: %s: %w", name, err)
+		}
+		levels.Set(name, level)
+	}
+	return levels, nil
+}
+
+// rules returns the RedactionRules specified by the Config.
+func (cfg Config) rules() ([]RedactionRule, error) {
+	rules := make([]RedactionRule, 0, len(cfg.Redact))
+	for i, rc := range cfg.Redact {
+		if len(rc.Names) == 0 {
+			return nil, fmt.Errorf("unilog: config: redact[%d]: names are required", i)
+		}
+
+		var r Redaction
+		switch strings.ToLower(rc.Strategy) {
+		case "", "mask":
+			r = Mask()
+		case "partial":
+			if rc.Keep < 0 {
+				return nil, fmt.Errorf("unilog: config: redact[%d]: invalid keep: %d", i, rc.Keep)
+			}
+			r = PartialMask(rc.Keep)
+		case "remove":
+			r = Remove()
+		case "hash":
+			r = Hash()
+		default:
+			return nil, fmt.Errorf("unilog: config: redact[%d]: invalid strategy: %q", i, rc.Strategy)
+		}
+		rules = append(rules, RedactNames(r, rc.Names...))
+	}
+	return rules, nil
+}
+
+// adapter returns the Adapter specified by the Sinks of the Config or, if
+// no Sinks are specified, the Format and Output.
+func (cfg Config) adapter() (Adapter, error) {
+	if len(cfg.Sinks) > 0 {
+		return openAdapter(cfg.Sinks...)
+	}
+
+	var enc encoder
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		enc = encodeText
+	case "json":
+		enc = encodeJSON
+	case "logfmt":
+		enc = encodeLogfmt
+	case "stdlog":
+		return &stdlogAdapter{fields: map[string]any{}}, nil
+	case "nul":
+		return &nulAdapter{}, nil
+	default:
+		return nil, fmt.Errorf("unilog: config: invalid format: %q", cfg.Format)
+	}
+
+	w, err := openOutput(cfg.Output)
+	if err != nil {
+		return nil, fmt.Errorf("unilog: config: output: %w", err)
+	}
+	return newWriterAdapter(w, enc), nil
+}
+
+// openOutput returns the writer identified by an output specification:
+// "stdout", "stderr" (or empty) or the path of a file, opened for appending
+// (and created if necessary) and reopened by `ReopenFiles()`.
+func openOutput(output string) (io.Writer, error) {
+	switch output {
+	case "", "stderr":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	default:
+		f, err := openFile(output)
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+}
@@ -0,0 +1,354 @@
+package unilog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultPollInterval is the interval at which a watched configuration file
+// is read, unless specified using `PollInterval()`.
+const defaultPollInterval = 5 * time.Second
+
+// WatchOption is an option for configuring a `ConfigWatcher`.
+type WatchOption func(*watchOptions)
+
+// watchOptions holds the options for a ConfigWatcher.
+type watchOptions struct {
+	interval time.Duration
+	signals  []os.Signal
+	decode   func([]byte, *Config) error
+}
+
+// PollInterval specifies the interval at which a watched configuration file
+// is read and any changes applied.  The default interval is 5 seconds; an
+// interval of zero (or less) disables polling.
+func PollInterval(d time.Duration) WatchOption {
+	return func(opts *watchOptions) { opts.interval = d }
+}
+
+// ReloadOnSignal specifies signals on receipt of which a watched configuration
+// file is reloaded, e.g. `syscall.SIGHUP`.
+func ReloadOnSignal(sig ...os.Signal) WatchOption {
+	return func(opts *watchOptions) { opts.signals = append(opts.signals, sig...) }
+}
+
+// ConfigDecoder specifies a func decoding a watched configuration file.  The
+// default decodes JSON; to decode YAML, specify the Unmarshal func of a YAML
+// package, e.g:
+//
+//	unilog.WatchConfig(path, unilog.ConfigDecoder(func(b []byte, cfg *unilog.Config) error {
+//		return yaml.Unmarshal(b, cfg)
+//	}))
+func ConfigDecoder(decode func([]byte, *Config) error) WatchOption {
+	return func(opts *watchOptions) { opts.decode = decode }
+}
+
+// ConfigWatcher maintains a Logger configured by a configuration file (see
+// `Config`), applying any changes to the file while the Logger is in use,
+// without a restart (see `WatchConfig()`).
+//
+// A ConfigWatcher is safe for concurrent use.
+type ConfigWatcher struct {
+	path     string
+	decode   func([]byte, *Config) error
+	sinks    *SwappableLogger
+	levels   *Levels
+	redactor *Redactor
+	log      Logger
+
+	mu      sync.Mutex // serialises reloads
+	applied bool       // true once a configuration has been applied
+	current Config     // the configuration currently applied
+	content []byte     // the content most recently read (applied or rejected)
+	readErr string     // the error most recently reported reading the file, if any
+
+	signals chan os.Signal
+	done    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+}
+
+// WatchConfig returns a ConfigWatcher maintaining a Logger configured by a
+// specified configuration file.  The file is read (every 5 seconds, or as
+// specified by `PollInterval()`, and on receipt of any signal specified by
+// `ReloadOnSignal()`) and any change to its content is validated and applied
+// to the levels, redaction rules and sinks of the Logger.  Any change is
+// effective immediately for the Logger and all Loggers and Entries
+// initialised from it.
+//
+// When a changed configuration is applied, an `Info` entry is emitted with a
+// "changes" field describing what changed.  An invalid configuration is
+// rejected, leaving the current configuration unchanged, and an `Error` entry
+// is emitted describing the error.
+//
+// An error is returned if the file cannot be read or the initial
+// configuration is not valid.  The ConfigWatcher should be closed (see
+// `Close()`) when no longer required.
+func WatchConfig(path string, opts ...WatchOption) (*ConfigWatcher, error) {
+	options := watchOptions{
+		interval: defaultPollInterval,
+		decode:   func(b []byte, cfg *Config) error { return json.Unmarshal(b, cfg) },
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	w := &ConfigWatcher{
+		path:     path,
+		decode:   options.decode,
+		sinks:    NewSwappableLogger(nil),
+		levels:   NewLevels(Info),
+		redactor: NewRedactor(),
+		done:     make(chan struct{}),
+	}
+	w.log = w.sinks.WithLevels(w.levels).WithRedactor(w.redactor)
+
+	content, cfg, err := w.read()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.apply(cfg); err != nil {
+		return nil, err
+	}
+	w.content = content
+
+	if len(options.signals) > 0 {
+		w.signals = make(chan os.Signal, 1)
+		signal.Notify(w.signals, options.signals...)
+	}
+
+	w.wg.Add(1)
+	go w.watch(options.interval)
+
+	return w, nil
+}
+
+// Logger returns the Logger maintained by the ConfigWatcher.
+func (w *ConfigWatcher) Logger() Logger {
+	return w.log
+}
+
+// Levels returns the Levels of the Logger maintained by the ConfigWatcher,
+// e.g. for use with `LevelsHandler()`.  Any change to the Levels is replaced
+// when a changed configuration is applied.
+func (w *ConfigWatcher) Levels() *Levels {
+	return w.levels
+}
+
+// Reload reads the configuration file and applies any changes, as when the
+// file is polled or a signal is received.  An error is returned if the
+// configuration is rejected.
+func (w *ConfigWatcher) Reload() error {
+	return w.reload(true)
+}
+
+// Close stops watching the configuration file.  The Logger remains
+// configured by the configuration most recently applied.
+func (w *ConfigWatcher) Close() error {
+	w.once.Do(func() {
+		if w.signals != nil {
+			signal.Stop(w.signals)
+		}
+		close(w.done)
+	})
+	w.wg.Wait()
+	return nil
+}
+
+// watch reloads the configuration at a specified interval and on receipt of
+// any signal, until the ConfigWatcher is closed.
+func (w *ConfigWatcher) watch(interval time.Duration) {
+	defer w.wg.Done()
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-tick:
+			_ = w.reload(false)
+		case <-w.signals:
+			_ = w.reload(true)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// reload reads the configuration file and applies any changes.  Unless
+// forced, the configuration is applied only if the content of the file has
+// changed since it was last read.
+func (w *ConfigWatcher) reload(force bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// an invalid configuration (or a file that cannot be read) is rejected
+	// once, not each time it is polled
+	content, cfg, err := w.read()
+	switch {
+	case content != nil:
+		if !force && bytes.Equal(content, w.content) {
+			return nil
+		}
+		w.content = content
+		w.readErr = ""
+	case !force && err.Error() == w.readErr:
+		return err
+	default:
+		w.readErr = err.Error()
+	}
+
+	// entries describing the reload are emitted regardless of the root level
+	entry := w.log.WithContext(ContextWithLevel(context.Background(), Info))
+
+	var changes []string
+	if err == nil {
+		changes, err = w.apply(cfg)
+	}
+	if err != nil {
+		entry.With(String("path", w.path), Err(err)).Error("configuration rejected")
+		return err
+	}
+
+	if len(changes) > 0 {
+		entry.With(String("path", w.path), Any("changes", changes)).Info("configuration reloaded")
+	}
+	return nil
+}
+
+// read returns the content of the configuration file and the Config decoded
+// from it.  If the content cannot be decoded, the content is returned with
+// the error.
+func (w *ConfigWatcher) read() ([]byte, Config, error) {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, Config{}, fmt.Errorf("unilog: config: %w", err)
+	}
+
+	var cfg Config
+	if err := w.decode(content, &cfg); err != nil {
+		return content, Config{}, fmt.Errorf("unilog: config: %s: %w", w.path, err)
+	}
+	return content, cfg, nil
+}
+
+// apply validates a Config and, if valid, applies it to the Logger, returning
+// a description of the changes from the Config currently applied.  If the
+// Config is not valid, the Logger is not modified.
+func (w *ConfigWatcher) apply(cfg Config) ([]string, error) {
+	levels, err := cfg.levels()
+	if err != nil {
+		return nil, err
+	}
+	rules, err := cfg.rules()
+	if err != nil {
+		return nil, err
+	}
+
+	// an unchanged configuration is not re-applied, preserving any levels
+	// changed at runtime (e.g. using `LevelsHandler()`)
+	changes := cfg.changes(w.current)
+	if w.applied && len(changes) == 0 {
+		return nil, nil
+	}
+
+	var adapter Adapter
+	if !w.applied || cfg.sinksChanged(w.current) {
+		if adapter, err = cfg.adapter(); err != nil {
+			return nil, err
+		}
+	}
+
+	settings := levels.Settings()
+	root := settings[""]
+	delete(settings, "")
+	w.levels.replace(root, settings)
+	w.redactor.SetRules(rules...)
+	if adapter != nil {
+		// Swap waits for entries being emitted to the replaced sinks
+		closeAdapter(w.sinks.Swap(adapter))
+	}
+	w.applied = true
+	w.current = cfg
+
+	return changes, nil
+}
+
+// sinksChanged returns true if the sinks specified by the Config differ from
+// those of another Config.
+func (cfg Config) sinksChanged(other Config) bool {
+	return !reflect.DeepEqual(cfg.sinks(), other.sinks())
+}
+
+// sinks returns the specifications of the sinks of the Config, or the Format
+// and Output if there are none.
+func (cfg Config) sinks() []string {
+	if len(cfg.Sinks) > 0 {
+		return cfg.Sinks
+	}
+	return []string{strings.ToLower(cfg.Format), cfg.Output}
+}
+
+// changes returns a description of the differences between the Config and
+// another (valid) Config, e.g. "level: info -> debug".
+func (cfg Config) changes(other Config) []string {
+	var changes []string
+	describe := func(name, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", name, from, to))
+		}
+	}
+
+	level := func(s string) string {
+		if s == "" {
+			return levelName(Info)
+		}
+		level, _ := ParseLevel(s)
+		return levelName(level)
+	}
+	describe("level", level(other.Level), level(cfg.Level))
+
+	names := map[string]bool{}
+	for name := range other.Levels {
+		names[name] = true
+	}
+	for name := range cfg.Levels {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		from, to := "(none)", "(none)"
+		if s, ok := other.Levels[name]; ok {
+			from = level(s)
+		}
+		if s, ok := cfg.Levels[name]; ok {
+			to = level(s)
+		}
+		describe("levels."+name, from, to)
+	}
+
+	if cfg.sinksChanged(other) {
+		describe("sinks", strings.Join(other.sinks(), " "), strings.Join(cfg.sinks(), " "))
+	}
+	if !reflect.DeepEqual(cfg.Redact, other.Redact) {
+		changes = append(changes, "redact")
+	}
+	return changes
+}
@@ -0,0 +1,375 @@
+package unilog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a configuration file.
+func writeConfig(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// readLines returns the lines of a file.
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestConfigWatcher(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	path := filepath.Join(dir, "unilog.json")
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+	writeConfig(t, path, `{"level":"warn","format":"logfmt","output":"`+first+`"}`)
+
+	sut, err := WatchConfig(path, PollInterval(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		_ = sut.Close()
+		closeAdapter(sut.sinks.Swap(nil))
+	}()
+	log := sut.Logger().Named("payments")
+
+	t.Run("initial configuration", func(t *testing.T) {
+		// ACT
+		log.NewEntry().Info("not emitted")
+		log.NewEntry().Warn("emitted")
+
+		// ASSERT
+		lines := readLines(t, first)
+		if len(lines) != 1 || !strings.Contains(lines[0], "level=warn msg=emitted") {
+			t.Errorf("unexpected output:\n%s", strings.Join(lines, "\n"))
+		}
+	})
+
+	t.Run("levels and redaction", func(t *testing.T) {
+		// ARRANGE
+		writeConfig(t, path, `{
+			"level": "warn",
+			"format": "logfmt",
+			"output": "`+first+`",
+			"levels": {"payments": "debug"},
+			"redact": [{"names": ["password"]}]
+		}`)
+
+		// ACT
+		err := sut.Reload()
+		log.NewEntry().WithField("password", "secret").Debug("emitted")
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines := readLines(t, first)
+		wanted := []string{
+			`msg="configuration reloaded" path=` + path + ` changes="[levels.payments: (none) -> debug redact]"`,
+			`level=debug msg=emitted logger=payments password=********`,
+		}
+		got := lines[len(lines)-2:]
+		for i := range wanted {
+			if !strings.Contains(got[i], wanted[i]) {
+				t.Errorf("\nwanted %s\ngot    %s", wanted[i], got[i])
+			}
+		}
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		// ARRANGE
+		writeConfig(t, path, `{"level":"verbose","format":"logfmt","output":"`+second+`"}`)
+
+		// ACT
+		err := sut.Reload()
+		log.NewEntry().Debug("still emitted")
+
+		// ASSERT
+		if err == nil {
+			t.Error("wanted error, got nil")
+		}
+		lines := readLines(t, first)
+		wanted := []string{
+			`level=error msg="configuration rejected" path=` + path + ` error="unilog: config: level: `,
+			`level=debug msg="still emitted"`,
+		}
+		got := lines[len(lines)-2:]
+		for i := range wanted {
+			if !strings.Contains(got[i], wanted[i]) {
+				t.Errorf("\nwanted %s\ngot    %s", wanted[i], got[i])
+			}
+		}
+		if _, err := os.Stat(second); err == nil {
+			t.Errorf("wanted no %s, got file", second)
+		}
+	})
+
+	t.Run("sinks", func(t *testing.T) {
+		// ARRANGE
+		writeConfig(t, path, `{"level":"warn","sinks":["json+file://`+second+`"]}`)
+		before := len(readLines(t, first))
+
+		// ACT
+		err := sut.Reload()
+		log.NewEntry().Warn("emitted")
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if after := len(readLines(t, first)); after != before {
+			t.Errorf("wanted %d entries in %s, got %d", before, first, after)
+		}
+		lines := readLines(t, second)
+		wanted := []string{
+			`"msg":"configuration reloaded","path":"` + path + `","changes":["levels.payments: debug -> (none)","sinks: logfmt ` + first + ` -> json+file://` + second + `","redact"]`,
+			`"level":"warn","msg":"emitted","logger":"payments"`,
+		}
+		if len(lines) != len(wanted) {
+			t.Fatalf("unexpected output:\n%s", strings.Join(lines, "\n"))
+		}
+		for i := range wanted {
+			if !strings.Contains(lines[i], wanted[i]) {
+				t.Errorf("\nwanted %s\ngot    %s", wanted[i], lines[i])
+			}
+		}
+	})
+}
+
+func TestConfigWatcherReload(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	path := filepath.Join(dir, "unilog.json")
+	output := filepath.Join(dir, "unilog.log")
+	config := `{"level":"warn","format":"logfmt","output":"` + output + `"}`
+	writeConfig(t, path, config)
+
+	sut, err := WatchConfig(path, PollInterval(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		_ = sut.Close()
+		closeAdapter(sut.sinks.Swap(nil))
+	}()
+
+	count := func(s string) int {
+		return strings.Count(strings.Join(readLines(t, output), "\n"), s)
+	}
+
+	t.Run("unchanged configuration", func(t *testing.T) {
+		// ARRANGE
+		sut.Levels().Set("payments", Trace)
+
+		// ACT
+		err := sut.Reload()
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wanted, got := Trace, sut.Levels().Level("payments"); wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+		if got := count("configuration reloaded"); got != 0 {
+			t.Errorf("wanted no reload entries, got %d", got)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		// ARRANGE
+		if err := os.Remove(path); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// ACT
+		errs := []error{sut.reload(false), sut.reload(false), sut.reload(false)}
+
+		// ASSERT
+		for _, err := range errs {
+			if err == nil {
+				t.Error("wanted error, got nil")
+			}
+		}
+		if wanted, got := 1, count("configuration rejected"); wanted != got {
+			t.Errorf("wanted %d rejected entries, got %d", wanted, got)
+		}
+	})
+
+	t.Run("file restored", func(t *testing.T) {
+		// ARRANGE
+		writeConfig(t, path, config)
+
+		// ACT
+		err := sut.reload(false)
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wanted, got := Trace, sut.Levels().Level("payments"); wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+}
+
+func TestConfigWatcherRejectsInvalidRedaction(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	path := filepath.Join(dir, "unilog.json")
+	output := filepath.Join(dir, "unilog.log")
+	writeConfig(t, path, `{"format":"logfmt","output":"`+output+`","redact":[{"names":["card"],"strategy":"partial","keep":4}]}`)
+
+	sut, err := WatchConfig(path, PollInterval(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		_ = sut.Close()
+		closeAdapter(sut.sinks.Swap(nil))
+	}()
+
+	// ACT
+	writeConfig(t, path, `{"format":"logfmt","output":"`+output+`","redact":[{"names":["card"],"strategy":"partial","keep":-1}]}`)
+	err = sut.Reload()
+	sut.Logger().NewEntry().WithField("card", "4111111111111111").Info("paid")
+
+	// ASSERT
+	if err == nil {
+		t.Error("wanted error, got nil")
+	}
+	lines := readLines(t, output)
+	wanted := []string{
+		`level=error msg="configuration rejected" path=` + path + ` error="unilog: config: redact[0]: invalid keep: -1"`,
+		`level=info msg=paid card=************1111`,
+	}
+	if len(lines) != len(wanted) {
+		t.Fatalf("unexpected output:\n%s", strings.Join(lines, "\n"))
+	}
+	for i := range wanted {
+		if !strings.Contains(lines[i], wanted[i]) {
+			t.Errorf("\nwanted %s\ngot    %s", wanted[i], lines[i])
+		}
+	}
+}
+
+func TestConfigWatcherEmitDuringReload(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	path := filepath.Join(dir, "unilog.json")
+	outputs := []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")}
+	config := func(i int) string {
+		return `{"format":"logfmt","output":"` + outputs[i%2] + `"}`
+	}
+	writeConfig(t, path, config(0))
+
+	sut, err := WatchConfig(path, PollInterval(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		_ = sut.Close()
+		closeAdapter(sut.sinks.Swap(nil))
+	}()
+	log := sut.Logger().NewEntry()
+
+	const emitters, entries = 4, 100
+
+	// ACT
+	wg := sync.WaitGroup{}
+	for i := 0; i < emitters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < entries; j++ {
+				log.Info("entry")
+			}
+		}()
+	}
+	for i := 1; i <= 10; i++ {
+		writeConfig(t, path, config(i))
+		if err := sut.Reload(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	wg.Wait()
+
+	// ASSERT
+	got := 0
+	for _, output := range outputs {
+		got += strings.Count(strings.Join(readLines(t, output), "\n"), "msg=entry")
+	}
+	if wanted := emitters * entries; wanted != got {
+		t.Errorf("wanted %d entries, got %d", wanted, got)
+	}
+}
+
+func TestConfigWatcherPolling(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "unilog.json")
+	writeConfig(t, path, `{"level":"info","format":"nul"}`)
+
+	sut, err := WatchConfig(path, PollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sut.Close()
+
+	// ACT
+	writeConfig(t, path, `{"level":"trace","format":"nul"}`)
+
+	// ASSERT
+	deadline := time.Now().Add(5 * time.Second)
+	for sut.levels.Level("") != Trace {
+		if time.Now().After(deadline) {
+			t.Fatalf("wanted %v, got %v", Trace, sut.levels.Level(""))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWatchConfigErrors(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	testcases := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing file"},
+		{name: "invalid json", content: `{"level":`},
+		{name: "invalid level", content: `{"level":"verbose"}`},
+		{name: "invalid redaction", content: `{"redact":[{"names":["password"],"strategy":"shred"}]}`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			path := filepath.Join(dir, tc.name+".json")
+			if tc.content != "" {
+				writeConfig(t, path, tc.content)
+			}
+
+			// ACT
+			w, err := WatchConfig(path)
+
+			// ASSERT
+			if err == nil || w != nil {
+				t.Errorf("wanted error, got %v (%v)", err, w)
+			}
+		})
+	}
+}
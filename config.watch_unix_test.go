@@ -0,0 +1,37 @@
+//go:build !windows
+
+package unilog
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestConfigWatcherReloadOnSignal(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "unilog.json")
+	writeConfig(t, path, `{"level":"info","format":"nul"}`)
+
+	sut, err := WatchConfig(path, PollInterval(0), ReloadOnSignal(syscall.SIGHUP))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sut.Close()
+	writeConfig(t, path, `{"level":"debug","format":"nul"}`)
+
+	// ACT
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// ASSERT
+	deadline := time.Now().Add(5 * time.Second)
+	for sut.levels.Level("") != Debug {
+		if time.Now().After(deadline) {
+			t.Fatalf("wanted %v, got %v", Debug, sut.levels.Level(""))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
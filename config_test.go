@@ -0,0 +1,179 @@
+package unilog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	// ARRANGE
+	t.Setenv(EnvLevel, "debug")
+	t.Setenv(EnvFormat, "json")
+	t.Setenv(EnvOutput, "stdout")
+	t.Setenv(EnvLevels, "payments=trace, payments.db = warn,,orders")
+
+	// ACT
+	got := ConfigFromEnv()
+
+	// ASSERT
+	wanted := Config{
+		Level:  "debug",
+		Format: "json",
+		Output: "stdout",
+		Levels: map[string]string{"payments": "trace", "payments.db": "warn", "orders": ""},
+	}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestConfigFromJSON(t *testing.T) {
+	// ARRANGE
+	data := `{"level":"warn","format":"logfmt","output":"stderr","levels":{"payments":"debug"}}`
+
+	// ACT
+	var got Config
+	err := json.Unmarshal([]byte(data), &got)
+
+	// ASSERT
+	wanted := Config{Level: "warn", Format: "logfmt", Output: "stderr", Levels: map[string]string{"payments": "debug"}}
+	if err != nil || !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v (%v)", wanted, got, err)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg := Config{
+		Level:  "warn",
+		Format: "logfmt",
+		Output: path,
+		Levels: map[string]string{"payments": "debug"},
+	}
+
+	// ACT
+	log, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer closeAdapter(log.(*logger).Adapter)
+	log.NewEntry().Info("not emitted")
+	log.NewEntry().Warn("root")
+	log.Named("payments").NewEntry().Debug("named")
+
+	// ASSERT
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 ||
+		!strings.Contains(lines[0], "level=warn msg=root") ||
+		!strings.Contains(lines[1], "level=debug msg=named logger=payments") {
+		t.Errorf("unexpected output:\n%s", data)
+	}
+}
+
+func TestFromConfigRedact(t *testing.T) {
+	// ARRANGE
+	log, err := FromConfig(Config{
+		Format: "nul",
+		Redact: []RedactConfig{
+			{Names: []string{"password"}, Strategy: "remove"},
+			{Names: []string{"card"}, Strategy: "partial", Keep: 4},
+			{Names: []string{"email"}, Strategy: "hash"},
+			{Names: []string{"*token*"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// ACT
+	got := log.(*logger).redactor.redact([]Field{
+		String("password", "secret"),
+		String("card", "4111111111111111"),
+		String("email", "user@example.com"),
+		String("auth_token", "abc"),
+	})
+
+	// ASSERT
+	values := map[string]any{}
+	for _, f := range got {
+		values[f.Key] = f.Value()
+	}
+	if _, ok := values["password"]; ok {
+		t.Errorf("wanted password removed, got %v", values["password"])
+	}
+	if wanted, got := "************1111", values["card"]; wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+	if got, _ := values["email"].(string); !strings.HasPrefix(got, "sha256:") {
+		t.Errorf("wanted sha256:..., got %v", got)
+	}
+	if wanted, got := "********", values["auth_token"]; wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
+
+func TestFromConfigAdapters(t *testing.T) {
+	testcases := []struct {
+		format string
+		result Adapter
+	}{
+		{format: "", result: newWriterAdapter(os.Stderr, encodeText)},
+		{format: "JSON", result: newWriterAdapter(os.Stderr, encodeJSON)},
+		{format: "stdlog", result: &stdlogAdapter{fields: map[string]any{}}},
+		{format: "nul", result: &nulAdapter{}},
+		{format: "sinks", result: &fanoutAdapter{}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.format, func(t *testing.T) {
+			// ACT
+			cfg := Config{Format: tc.format}
+			if tc.format == "sinks" {
+				cfg.Sinks = []string{"nul://", "stdlog://"}
+			}
+			log, err := FromConfig(cfg)
+
+			// ASSERT
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			wanted := reflect.TypeOf(tc.result)
+			got := reflect.TypeOf(log.(*logger).Adapter)
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestFromConfigErrors(t *testing.T) {
+	testcases := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "invalid level", cfg: Config{Level: "verbose"}},
+		{name: "invalid named level", cfg: Config{Levels: map[string]string{"payments": ""}}},
+		{name: "invalid format", cfg: Config{Format: "xml"}},
+		{name: "invalid sink", cfg: Config{Sinks: []string{"xml://"}}},
+		{name: "redaction without names", cfg: Config{Redact: []RedactConfig{{Strategy: "mask"}}}},
+		{name: "invalid redaction keep", cfg: Config{Redact: []RedactConfig{{Names: []string{"password"}, Strategy: "partial", Keep: -1}}}},
+		{name: "invalid redaction strategy", cfg: Config{Redact: []RedactConfig{{Names: []string{"password"}, Strategy: "shred"}}}},
+		{name: "invalid output", cfg: Config{Output: filepath.Join(t.TempDir(), "missing", "app.log")}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			log, err := FromConfig(tc.cfg)
+
+			// ASSERT
+			if err == nil || log != nil {
+				t.Errorf("wanted error, got %v (%v)", err, log)
+			}
+		})
+	}
+}
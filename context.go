@@ -6,7 +6,12 @@ import (
 
 type contextKey int
 
-const loggerContextKey contextKey = iota
+const (
+	loggerContextKey contextKey = iota
+	levelContextKey
+	recorderContextKey
+	scopeContextKey
+)
 
 // ContextWithLogger adds a Logger reference to a parent context.  The new context
 // containing the Logger is returned.
@@ -22,35 +27,54 @@ func ContextWithLogger(ctx context.Context, log Logger) context.Context {
 // if a `Logger` is found, it is used to initialise a new `Entry` from
 // the context which is then returned.
 //
-// If the context does not contain a `Logger`, `nil` is returned.
+// If the context does not contain a `Logger`, the default `Logger` (see
+// `Default()`) is used; by default this is `Nul()`, so the result may be
+// used without checking for `nil`.
 //
 // NOTE: This function is intended to be used in modules that choose to accept
 // a `Logger` supplied via a context, rather than providing a specific
 // configuration variable or field.  This may be desirable where `Logger`
 // support is added without wishing to break existing configuration contracts.
 func LogFromContext(ctx context.Context) Entry {
-	log := ctx.Value(loggerContextKey)
-	if log == nil {
-		return nil
-	}
-
-	return log.(Logger).WithContext(ctx)
+	return LoggerFromContext(ctx).WithContext(ctx)
 }
 
 // LoggerFromContext inspects a specified context for a `unilog.Logger`;
 // if a `Logger` is found it is returned.
 //
-// If the context does not contain a `Logger`, `nil` is returned.
+// If the context does not contain a `Logger`, the default `Logger` (see
+// `Default()`) is returned.
 //
 // NOTE: This function is intended to be used in modules that choose to accept
 // a `Logger` supplied via a context, rather than providing a specific
 // configuration variable or field.  This may be desirable where `Logger`
 // support is added without wishing to break existing configuration contracts.
 func LoggerFromContext(ctx context.Context) Logger {
-	log := ctx.Value(loggerContextKey)
-	if log == nil {
-		return nil
+	if log, ok := ctx.Value(loggerContextKey).(Logger); ok {
+		return log
 	}
+	return Default()
+}
 
-	return log.(Logger)
+// ContextWithLevel returns a new context, derived from a parent context, with
+// a specified level overriding the level of entries emitted by any `Entry`
+// initialised from it (e.g. using `WithContext()` or `LogFromContext()`).
+//
+// This allows the verbosity of logging to be increased (or reduced) for a
+// specific operation, such as a single request, without affecting any other.
+// The level replaces any level determined by `Levels` and any level applied
+// by an `Adapter` implementing `LevelEnabler`; note however that an Adapter
+// may still discard entries according to its own configuration.
+func ContextWithLevel(ctx context.Context, level Level) context.Context {
+	return context.WithValue(ctx, levelContextKey, level)
+}
+
+// LevelFromContext returns any level set in a specified context using
+// `ContextWithLevel()`.  If the context has no level, false is returned.
+func LevelFromContext(ctx context.Context) (Level, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	level, ok := ctx.Value(levelContextKey).(Level)
+	return level, ok
 }
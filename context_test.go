@@ -31,10 +31,10 @@ func TestLogFromContext(t *testing.T) {
 		entry := LogFromContext(ctx)
 
 		// ASSERT
-		wanted := (Entry)(nil)
-		got := entry
-		if wanted != got {
-			t.Errorf("wanted %#v, got %#v", wanted, got)
+		wanted := Default().WithContext(ctx).(*logger)
+		got := entry.(*logger)
+		if !reflect.DeepEqual(*wanted, *got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
 		}
 	})
 
@@ -64,7 +64,7 @@ func TestLoggerFromContext(t *testing.T) {
 		result := LoggerFromContext(ctx)
 
 		// ASSERT
-		wanted := (Logger)(nil)
+		wanted := Default()
 		got := result
 		if wanted != got {
 			t.Errorf("wanted %#v, got %#v", wanted, got)
@@ -87,3 +87,58 @@ func TestLoggerFromContext(t *testing.T) {
 		}
 	})
 }
+
+func TestContextWithLevel(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+
+	// ACT
+	ctx = ContextWithLevel(ctx, Trace)
+
+	// ASSERT
+	wanted := Trace
+	got, ok := LevelFromContext(ctx)
+	if !ok || wanted != got {
+		t.Errorf("wanted %v, got %v (%v)", wanted, got, ok)
+	}
+
+	if _, ok := LevelFromContext(context.Background()); ok {
+		t.Errorf("wanted no level in context without level")
+	}
+}
+
+func TestContextWithLevelOverridesThreshold(t *testing.T) {
+	testcases := []struct {
+		name    string
+		ctx     context.Context
+		level   Level
+		emitted bool
+	}{
+		{name: "no override/below threshold", ctx: context.Background(), level: Debug},
+		{name: "no override/at threshold", ctx: context.Background(), level: Info, emitted: true},
+		{name: "override/trace", ctx: ContextWithLevel(context.Background(), Trace), level: Trace, emitted: true},
+		{name: "override/error", ctx: ContextWithLevel(context.Background(), Error), level: Info},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := &fieldAdapter{level: Debug}
+			log := UsingAdapter(context.Background(), adapter).WithLevels(NewLevels(Info))
+			ctx := ContextWithLogger(tc.ctx, log)
+
+			// ACT
+			LogFromContext(ctx).Emit(tc.level, "entry")
+			log.WithContext(tc.ctx).Emit(tc.level, "entry")
+
+			// ASSERT
+			wanted := 0
+			if tc.emitted {
+				wanted = 2
+			}
+			got := adapter.calls
+			if wanted != got {
+				t.Errorf("wanted %d, got %d", wanted, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package unilog
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// defaultLogger holds the default Logger; atomic.Value requires a consistent
+// concrete type, so the Logger is wrapped in this struct.
+type defaultLogger struct {
+	Logger
+}
+
+var defaultValue atomic.Value
+
+func init() {
+	defaultValue.Store(defaultLogger{Nul()})
+}
+
+// Default returns the default Logger, used by `LogFromContext()` and
+// `LoggerFromContext()` (and the package-level logging functions) when a
+// context does not contain a Logger.  Unless set using `SetDefault()`, the
+// default Logger is `Nul()`.
+func Default() Logger {
+	return defaultValue.Load().(defaultLogger).Logger
+}
+
+// SetDefault sets the default Logger (see `Default()`).  Setting a nil
+// Logger restores the initial default, `Nul()`.
+//
+// SetDefault is safe for concurrent use, though it is typically called once,
+// when an application has configured its Logger.
+func SetDefault(log Logger) {
+	if log == nil {
+		log = Nul()
+	}
+	defaultValue.Store(defaultLogger{log})
+}
+
+// Log emits an entry at a specified level using an `Entry` initialised from
+// a specified context (see `LogFromContext()`), i.e. using any Logger in
+// the context or the default Logger.
+//
+// Package-level functions are not provided for each level since their names
+// would collide with the Level constants; `Log()` and the formatting
+// functions (`Tracef()`, `Debugf()` etc) are provided instead.
+func Log(ctx context.Context, level Level, s string) {
+	LogFromContext(ctx).Emit(level, s)
+}
+
+// Tracef emits a `Trace` level entry using a format string and args, using
+// an `Entry` initialised from a specified context (see `Log()`).
+func Tracef(ctx context.Context, format string, args ...any) {
+	LogFromContext(ctx).Tracef(format, args...)
+}
+
+// Debugf emits a `Debug` level entry using a format string and args, using
+// an `Entry` initialised from a specified context (see `Log()`).
+func Debugf(ctx context.Context, format string, args ...any) {
+	LogFromContext(ctx).Debugf(format, args...)
+}
+
+// Infof emits an `Info` level entry using a format string and args, using
+// an `Entry` initialised from a specified context (see `Log()`).
+func Infof(ctx context.Context, format string, args ...any) {
+	LogFromContext(ctx).Infof(format, args...)
+}
+
+// Warnf emits a `Warn` level entry using a format string and args, using
+// an `Entry` initialised from a specified context (see `Log()`).
+func Warnf(ctx context.Context, format string, args ...any) {
+	LogFromContext(ctx).Warnf(format, args...)
+}
+
+// Errorf emits an `Error` level entry using a format string and args, using
+// an `Entry` initialised from a specified context (see `Log()`).  As with
+// `Entry.Errorf()`, the entry is enriched with the context of any error in
+// the args (see `errorcontext`).
+func Errorf(ctx context.Context, format string, args ...any) {
+	LogFromContext(ctx).Errorf(format, args...)
+}
+
+// LogError emits an error (or other value) as an `Error` level entry using
+// an `Entry` initialised from a specified context (see `Log()` and
+// `Entry.Error()`).
+func LogError(ctx context.Context, err any) {
+	LogFromContext(ctx).Error(err)
+}
@@ -0,0 +1,113 @@
+package unilog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	// ARRANGE
+	og := Default()
+	defer SetDefault(og)
+
+	t.Run("initial", func(t *testing.T) {
+		wanted := Nul()
+		got := Default()
+		if wanted != got {
+			t.Errorf("wanted %#v, got %#v", wanted, got)
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		// ARRANGE
+		log := StdLog()
+
+		// ACT
+		SetDefault(log)
+
+		// ASSERT
+		wanted := log
+		got := Default()
+		if wanted != got {
+			t.Errorf("wanted %#v, got %#v", wanted, got)
+		}
+	})
+
+	t.Run("set nil", func(t *testing.T) {
+		// ACT
+		SetDefault(nil)
+
+		// ASSERT
+		wanted := Nul()
+		got := Default()
+		if wanted != got {
+			t.Errorf("wanted %#v, got %#v", wanted, got)
+		}
+	})
+}
+
+func TestPackageLevelFuncs(t *testing.T) {
+	// ARRANGE
+	og := Default()
+	defer SetDefault(og)
+
+	testcases := []struct {
+		name   string
+		fn     func(context.Context)
+		result recordedEntry
+	}{
+		{name: "log", fn: func(ctx context.Context) { Log(ctx, Warn, "message") }, result: recordedEntry{level: Warn, s: "message"}},
+		{name: "tracef", fn: func(ctx context.Context) { Tracef(ctx, "value: %d", 1) }, result: recordedEntry{level: Trace, s: "value: 1"}},
+		{name: "debugf", fn: func(ctx context.Context) { Debugf(ctx, "value: %d", 1) }, result: recordedEntry{level: Debug, s: "value: 1"}},
+		{name: "infof", fn: func(ctx context.Context) { Infof(ctx, "value: %d", 1) }, result: recordedEntry{level: Info, s: "value: 1"}},
+		{name: "warnf", fn: func(ctx context.Context) { Warnf(ctx, "value: %d", 1) }, result: recordedEntry{level: Warn, s: "value: 1"}},
+		{name: "errorf", fn: func(ctx context.Context) { Errorf(ctx, "value: %d", 1) }, result: recordedEntry{level: Error, s: "value: 1"}},
+		{name: "logerror", fn: func(ctx context.Context) { LogError(ctx, errors.New("error")) }, result: recordedEntry{level: Error, s: "error"}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("logger in context", func(t *testing.T) {
+				// ARRANGE
+				SetDefault(nil)
+				adapter := newRecordingAdapter()
+				ctx := ContextWithLogger(context.Background(), UsingAdapter(context.Background(), adapter))
+
+				// ACT
+				tc.fn(ctx)
+
+				// ASSERT
+				wanted := []recordedEntry{tc.result}
+				got := *adapter.entries
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+				}
+			})
+
+			t.Run("default logger", func(t *testing.T) {
+				// ARRANGE
+				adapter := newRecordingAdapter()
+				SetDefault(UsingAdapter(context.Background(), adapter))
+
+				// ACT
+				tc.fn(context.Background())
+
+				// ASSERT
+				wanted := []recordedEntry{tc.result}
+				got := *adapter.entries
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+				}
+			})
+
+			t.Run("no logger", func(t *testing.T) {
+				// ARRANGE
+				SetDefault(nil)
+
+				// ACT
+				tc.fn(context.Background()) // does not panic
+			})
+		})
+	}
+}
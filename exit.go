@@ -1,6 +1,9 @@
 package unilog
 
-import "os"
+import (
+	"fmt"
+	"os"
+)
 
 // ExitFn is a func var allowing `unilog` code paths that reach an `os.Exit` call
 // to be replaced by a non-exiting behaviour.
@@ -18,3 +21,45 @@ var ExitFn func(int) = os.Exit
 func exit(code int) {
 	ExitFn(code)
 }
+
+// ExitBehaviour is a func called by a Logger following the emission of a
+// `Fatal` entry, with the exit code and message of the entry.  An
+// ExitBehaviour is set on a Logger using `WithExitBehaviour()`; a Logger
+// with no ExitBehaviour calls `ExitFn`.
+//
+// Any func may be used as an ExitBehaviour; the following are provided:
+//
+//	ExitProcess()  - calls ExitFn (the default)
+//	PanicOnFatal() - panics with a FatalPanic value
+//	NoExit()       - does nothing; the Fatal call returns to the caller
+type ExitBehaviour func(code int, msg string)
+
+// FatalPanic is the value recovered from the panic raised by the
+// `PanicOnFatal()` exit behaviour.
+type FatalPanic struct {
+	Code    int
+	Message string
+}
+
+// String implements the Stringer interface for a FatalPanic.
+func (p FatalPanic) String() string {
+	return fmt.Sprintf("fatal (exit code %d): %s", p.Code, p.Message)
+}
+
+// ExitProcess returns an ExitBehaviour that calls `ExitFn`.  This is the
+// default behaviour of a Logger.
+func ExitProcess() ExitBehaviour {
+	return func(code int, _ string) { exit(code) }
+}
+
+// PanicOnFatal returns an ExitBehaviour that panics with a `FatalPanic`
+// value, allowing a caller to recover from a Fatal entry.
+func PanicOnFatal() ExitBehaviour {
+	return func(code int, msg string) { panic(FatalPanic{Code: code, Message: msg}) }
+}
+
+// NoExit returns an ExitBehaviour that does nothing; a `Fatal` entry will
+// return to the caller as for any other level.
+func NoExit() ExitBehaviour {
+	return func(int, string) {}
+}
@@ -0,0 +1,76 @@
+package unilog
+
+import "testing"
+
+func TestExitBehaviours(t *testing.T) {
+	// ARRANGE
+	ofn := ExitFn
+	defer func() { ExitFn = ofn }()
+
+	var exitCode *int
+	ExitFn = func(code int) { exitCode = &code }
+
+	t.Run("ExitProcess", func(t *testing.T) {
+		// ARRANGE
+		exitCode = nil
+
+		// ACT
+		ExitProcess()(1, "message")
+
+		// ASSERT
+		if exitCode == nil {
+			t.Fatal("ExitFn was not called")
+		}
+		wanted := 1
+		got := *exitCode
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("PanicOnFatal", func(t *testing.T) {
+		// ARRANGE
+		exitCode = nil
+		defer func() {
+			// ASSERT
+			wanted := FatalPanic{Code: 1, Message: "message"}
+			got := recover()
+			if wanted != got {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+			if exitCode != nil {
+				t.Error("ExitFn was called")
+			}
+		}()
+
+		// ACT
+		PanicOnFatal()(1, "message")
+	})
+
+	t.Run("NoExit", func(t *testing.T) {
+		// ARRANGE
+		exitCode = nil
+
+		// ACT
+		NoExit()(1, "message")
+
+		// ASSERT
+		if exitCode != nil {
+			t.Error("ExitFn was called")
+		}
+	})
+}
+
+func TestFatalPanicString(t *testing.T) {
+	// ARRANGE
+	sut := FatalPanic{Code: 1, Message: "message"}
+
+	// ACT
+	got := sut.String()
+
+	// ASSERT
+	wanted := "fatal (exit code 1): message"
+	if wanted != got {
+		t.Errorf("wanted %q, got %q", wanted, got)
+	}
+}
@@ -0,0 +1,231 @@
+package unilog
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// FieldKind identifies the type of value held by a Field.
+type FieldKind uint8
+
+const (
+	AnyKind      FieldKind = iota // the value of the Field is any value (see Field.Value())
+	StringKind                    // the value of the Field is a string (see Field.AsString())
+	Int64Kind                     // the value of the Field is an int64 (see Field.AsInt64())
+	BoolKind                      // the value of the Field is a bool (see Field.AsBool())
+	DurationKind                  // the value of the Field is a time.Duration (see Field.AsDuration())
+	TimeKind                      // the value of the Field is a time.Time (see Field.AsTime())
+	ErrorKind                     // the value of the Field is an error (see Field.AsError())
+	GroupKind                     // the value of the Field is a group of Fields (see Field.AsGroup())
+)
+
+// Field is a named value to be added to a log entry.  Fields are initialised
+// using typed constructors (`String()`, `Int64()`, etc) and added to an Entry
+// using `With()`.
+//
+// A Field holds values of most supported types without boxing them in
+// an interface, allowing Adapters implementing `FieldAdapter` to consume
+// them without any allocation.
+type Field struct {
+	Key  string
+	Kind FieldKind
+	num  int64
+	str  string
+	obj  any
+}
+
+// Any returns a Field with a specified key and value of any type.
+func Any(key string, value any) Field {
+	return Field{Key: key, Kind: AnyKind, obj: value}
+}
+
+// Bool returns a Field with a specified key and bool value.
+func Bool(key string, value bool) Field {
+	var n int64
+	if value {
+		n = 1
+	}
+	return Field{Key: key, Kind: BoolKind, num: n}
+}
+
+// Duration returns a Field with a specified key and time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Kind: DurationKind, num: int64(value)}
+}
+
+// Err returns a Field with the key "error" and a specified error value.
+func Err(err error) Field {
+	return Field{Key: "error", Kind: ErrorKind, obj: err}
+}
+
+// Int64 returns a Field with a specified key and int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Kind: Int64Kind, num: value}
+}
+
+// String returns a Field with a specified key and string value.
+func String(key string, value string) Field {
+	return Field{Key: key, Kind: StringKind, str: value}
+}
+
+// minTime and maxTime are the range of time.Time values that can be
+// represented as int64 nanoseconds since the Unix epoch.
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// Time returns a Field with a specified key and time.Time value.
+func Time(key string, value time.Time) Field {
+	if value.Before(minTime) || value.After(maxTime) {
+		return Any(key, value)
+	}
+	return Field{Key: key, Kind: TimeKind, num: value.UnixNano(), obj: value.Location()}
+}
+
+// AsBool returns the value of a BoolKind Field.
+func (f Field) AsBool() bool {
+	return f.num == 1
+}
+
+// AsDuration returns the value of a DurationKind Field.
+func (f Field) AsDuration() time.Duration {
+	return time.Duration(f.num)
+}
+
+// AsError returns the value of an ErrorKind Field.
+func (f Field) AsError() error {
+	err, _ := f.obj.(error)
+	return err
+}
+
+// AsInt64 returns the value of an Int64Kind Field.
+func (f Field) AsInt64() int64 {
+	return f.num
+}
+
+// AsString returns the value of a StringKind Field.
+func (f Field) AsString() string {
+	return f.str
+}
+
+// AsTime returns the value of a TimeKind Field.
+func (f Field) AsTime() time.Time {
+	loc, _ := f.obj.(*time.Location)
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Unix(0, f.num).In(loc)
+}
+
+// Value returns the value of a Field of any kind as an `any`.
+func (f Field) Value() any {
+	switch f.Kind {
+	case StringKind:
+		return f.str
+	case Int64Kind:
+		return f.num
+	case BoolKind:
+		return f.AsBool()
+	case DurationKind:
+		return f.AsDuration()
+	case TimeKind:
+		return f.AsTime()
+	default:
+		return f.obj
+	}
+}
+
+// withFields returns a copy of a slice of Fields with additional Fields
+// appended.  Any existing Field with the same key as an additional Field
+// is replaced (in its original position), except that the members of a
+// Group added where a Group with the same key exists are merged into it.
+func withFields(fields []Field, add ...Field) []Field {
+	result := make([]Field, len(fields), len(fields)+len(add))
+	copy(result, fields)
+
+	for _, f := range add {
+		result = setField(result, f)
+	}
+	return result
+}
+
+// setField sets a Field in a slice of Fields, replacing any existing Field
+// with the same key (or merging a Group with an existing Group) or appending
+// the Field if there is none.  The (possibly reallocated) slice is returned.
+func setField(fields []Field, f Field) []Field {
+	if f.Kind == GroupKind {
+		for i := range fields {
+			if fields[i].Key == f.Key && fields[i].Kind == GroupKind {
+				fields[i] = Group(f.Key, withFields(fields[i].AsGroup(), f.AsGroup()...)...)
+				return fields
+			}
+		}
+	}
+	return replaceField(fields, f)
+}
+
+// replaceField sets a Field in a slice of Fields, replacing any existing
+// Field with the same key or appending the Field if there is none.  The
+// (possibly reallocated) slice is returned.
+func replaceField(fields []Field, f Field) []Field {
+	for i := range fields {
+		if fields[i].Key == f.Key {
+			fields[i] = f
+			return fields
+		}
+	}
+	return append(fields, f)
+}
+
+// badKey is the key of a Field added for an arg to `WithValues()` that is
+// neither a Field nor the key of a key/value pair.
+const badKey = "!BADKEY"
+
+// argsToFields returns a slice of Fields from args that may contain any
+// combination of Field values and key/value pairs (as supported by
+// `log/slog`):
+//
+//   - a Field is used as-is;
+//   - a string is the key of a key/value pair, with the value in the
+//     following arg;
+//   - a string without a following value, or any other value where a
+//     key is expected, is added as the value of a "!BADKEY" field.
+func argsToFields(args []any) []Field {
+	fields := make([]Field, 0, len(args))
+	for len(args) > 0 {
+		switch arg := args[0].(type) {
+		case Field:
+			fields = append(fields, arg)
+			args = args[1:]
+		case string:
+			if len(args) == 1 {
+				fields = append(fields, Any(badKey, arg))
+				return fields
+			}
+			fields = append(fields, Any(arg, args[1]))
+			args = args[2:]
+		default:
+			fields = append(fields, Any(badKey, arg))
+			args = args[1:]
+		}
+	}
+	return fields
+}
+
+// mapToFields returns a slice of Fields for the named values in a map,
+// in key order.
+func mapToFields(m map[string]any) []Field {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]Field, len(keys))
+	for i, k := range keys {
+		fields[i] = Any(k, m[k])
+	}
+	return fields
+}
@@ -0,0 +1,131 @@
+package unilog
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFieldConstructors(t *testing.T) {
+	// ARRANGE
+	err := errors.New("error")
+	tm := time.Date(2010, 9, 8, 7, 6, 5, 4, time.UTC)
+	farFuture := time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name  string
+		field Field
+		key   string
+		kind  FieldKind
+		value any
+		typed any
+	}{
+		{name: "any", field: Any("key", []int{1}), key: "key", kind: AnyKind, value: []int{1}, typed: []int{1}},
+		{name: "bool", field: Bool("key", true), key: "key", kind: BoolKind, value: true, typed: Bool("key", true).AsBool()},
+		{name: "duration", field: Duration("key", time.Second), key: "key", kind: DurationKind, value: time.Second, typed: Duration("key", time.Second).AsDuration()},
+		{name: "err", field: Err(err), key: "error", kind: ErrorKind, value: err, typed: Err(err).AsError()},
+		{name: "int64", field: Int64("key", 42), key: "key", kind: Int64Kind, value: int64(42), typed: Int64("key", 42).AsInt64()},
+		{name: "string", field: String("key", "value"), key: "key", kind: StringKind, value: "value", typed: String("key", "value").AsString()},
+		{name: "time", field: Time("key", tm), key: "key", kind: TimeKind, value: tm, typed: Time("key", tm).AsTime()},
+		{name: "time (out of range)", field: Time("key", farFuture), key: "key", kind: AnyKind, value: farFuture, typed: farFuture},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("key", func(t *testing.T) {
+				wanted := tc.key
+				got := tc.field.Key
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			t.Run("kind", func(t *testing.T) {
+				wanted := tc.kind
+				got := tc.field.Kind
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			t.Run("value", func(t *testing.T) {
+				wanted := tc.value
+				got := tc.field.Value()
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("wanted %#v, got %#v", wanted, got)
+				}
+			})
+
+			t.Run("typed value", func(t *testing.T) {
+				wanted := tc.value
+				got := tc.typed
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("wanted %#v, got %#v", wanted, got)
+				}
+			})
+		})
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	// ARRANGE
+	fields := []Field{String("a", "1"), String("b", "2")}
+
+	// ACT
+	result := withFields(fields, String("c", "3"), String("a", "4"))
+
+	// ASSERT
+	t.Run("result", func(t *testing.T) {
+		wanted := []Field{String("a", "4"), String("b", "2"), String("c", "3")}
+		got := result
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+
+	t.Run("original fields unmodified", func(t *testing.T) {
+		wanted := []Field{String("a", "1"), String("b", "2")}
+		got := fields
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+}
+
+func TestArgsToFields(t *testing.T) {
+	testcases := []struct {
+		name   string
+		args   []any
+		result []Field
+	}{
+		{name: "no args", args: []any{}, result: []Field{}},
+		{name: "fields", args: []any{String("a", "1"), Int64("b", 2)}, result: []Field{String("a", "1"), Int64("b", 2)}},
+		{name: "key/value pairs", args: []any{"a", "1", "b", 2}, result: []Field{Any("a", "1"), Any("b", 2)}},
+		{name: "fields and key/value pairs", args: []any{"a", "1", Int64("b", 2), "c", true}, result: []Field{Any("a", "1"), Int64("b", 2), Any("c", true)}},
+		{name: "odd length", args: []any{"a", "1", "b"}, result: []Field{Any("a", "1"), Any("!BADKEY", "b")}},
+		{name: "non-string key", args: []any{42, "a", "1"}, result: []Field{Any("!BADKEY", 42), Any("a", "1")}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got := argsToFields(tc.args)
+
+			// ASSERT
+			wanted := tc.result
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestMapToFields(t *testing.T) {
+	// ACT
+	got := mapToFields(map[string]any{"b": 2, "a": "1", "c": true})
+
+	// ASSERT
+	wanted := []Field{Any("a", "1"), Any("b", 2), Any("c", true)}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
@@ -0,0 +1,118 @@
+package unilog
+
+import (
+	"os"
+	"sync"
+)
+
+// files is the set of files opened for writing entries (e.g. by `Open()` or
+// `FromConfig()`), reopened by `ReopenFiles()`.
+var (
+	filesMu sync.Mutex
+	files   = map[*reopenableFile]struct{}{}
+)
+
+// reopenableFile is an io.WriteCloser appending to a file that may be
+// reopened, e.g. after the file has been rotated.
+type reopenableFile struct {
+	mu     sync.Mutex
+	path   string
+	f      *os.File
+	closed bool // true once closed; a closed file is not reopened
+}
+
+// openFile opens a file for appending (creating it if necessary), adding it
+// to the set of files reopened by `ReopenFiles()`.
+func openFile(path string) (*reopenableFile, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	rf := &reopenableFile{path: path, f: f}
+
+	filesMu.Lock()
+	defer filesMu.Unlock()
+	files[rf] = struct{}{}
+	return rf, nil
+}
+
+// Write appends to the file.
+func (rf *reopenableFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.f.Write(p)
+}
+
+// Close closes the file, removing it from the set of files reopened by
+// `ReopenFiles()`.
+func (rf *reopenableFile) Close() error {
+	filesMu.Lock()
+	delete(files, rf)
+	filesMu.Unlock()
+
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	rf.closed = true
+	return rf.f.Close()
+}
+
+// reopen opens the path of the file, replacing the file currently open, and
+// returns true.  If the path cannot be opened, the current file remains open.
+// If the file has been closed (e.g. after the set of files to be reopened was
+// obtained by `reopenFiles()`), it is not reopened and false is returned.
+func (rf *reopenableFile) reopen() (bool, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return false, nil
+	}
+
+	f, err := os.OpenFile(rf.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return false, err
+	}
+	old := rf.f
+	rf.f = f
+	return true, old.Close()
+}
+
+// ReopenFiles reopens all files to which entries are written by Loggers
+// initialised by this package (e.g. using `Open()` or `FromConfig()`).  This
+// supports log rotation by tools such as logrotate: once a file has been
+// renamed, entries continue to be written to the renamed file until the
+// file is reopened, after which they are written to a new file at the
+// original path (see also `HandleSignals()`).
+//
+// All files are reopened; if any file cannot be reopened, it remains open and
+// the first error is returned.
+func ReopenFiles() error {
+	_, err := reopenFiles()
+	return err
+}
+
+// reopenFiles reopens all files, returning the number of files reopened.
+func reopenFiles() (int, error) {
+	filesMu.Lock()
+	open := make([]*reopenableFile, 0, len(files))
+	for rf := range files {
+		open = append(open, rf)
+	}
+	filesMu.Unlock()
+
+	n := 0
+	var result error
+	for _, rf := range open {
+		reopened, err := rf.reopen()
+		if err != nil {
+			if result == nil {
+				result = err
+			}
+			continue
+		}
+		if reopened {
+			n++
+		}
+	}
+	return n, result
+}
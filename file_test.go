@@ -0,0 +1,96 @@
+package unilog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReopenFiles(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rotated := filepath.Join(dir, "app.log.1")
+
+	log, err := Open("text+file://" + path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer closeAdapter(log.(*logger).Adapter)
+	entry := log.NewEntry()
+	entry.Info("before")
+
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry.Info("rotated")
+
+	// ACT
+	err = ReopenFiles()
+	entry.Info("reopened")
+
+	// ASSERT
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testcases := []struct {
+		path   string
+		result int
+	}{
+		{path: rotated, result: 2},
+		{path: path, result: 1},
+	}
+	for _, tc := range testcases {
+		t.Run(filepath.Base(tc.path), func(t *testing.T) {
+			wanted := tc.result
+			got := len(readLines(t, tc.path))
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestReopenFilesClosed(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := openFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// ACT
+	_ = f.Close()
+
+	// ASSERT
+	filesMu.Lock()
+	_, ok := files[f]
+	filesMu.Unlock()
+	if ok {
+		t.Error("wanted closed file removed, but it was not")
+	}
+}
+
+func TestReopenClosedFile(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := openFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = f.Close()
+
+	// ACT
+	reopened, err := f.reopen()
+
+	// ASSERT
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if reopened {
+		t.Error("wanted closed file not reopened, but it was")
+	}
+	if _, err := f.Write([]byte("entry\n")); err == nil {
+		t.Error("wanted error writing to closed file, got nil")
+	}
+}
@@ -0,0 +1,110 @@
+package unilog
+
+// Group returns a Field with a specified key and a group of Fields as its
+// value.  A Group field added to an entry (using `With()`) is equivalent
+// to adding its member fields to an entry initialised using `WithGroup()`.
+//
+// Adapters implementing `FieldAdapter` receive any group of fields as a
+// Group field.
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, Kind: GroupKind, obj: fields}
+}
+
+// AsGroup returns the Fields of a GroupKind Field.
+func (f Field) AsGroup() []Field {
+	fields, _ := f.obj.([]Field)
+	return fields
+}
+
+// Flatten returns a slice of Fields with the Fields of any Group replaced
+// by fields with keys qualified by the key of the group (and any parent
+// groups), separated by '.'.  e.g. a Field "id" in a Group "db" is returned
+// with the key "db.id".
+//
+// Flatten is provided for use by Adapters implementing `FieldAdapter` that
+// do not support groups.  If there are no Group fields the fields are
+// returned unmodified.
+func Flatten(fields []Field) []Field {
+	if !hasGroup(fields) {
+		return fields
+	}
+	return flatten(make([]Field, 0, len(fields)), "", fields)
+}
+
+// flatten appends the fields in a group to a slice, with keys qualified by
+// a prefix, returning the (possibly reallocated) slice.
+func flatten(result []Field, prefix string, fields []Field) []Field {
+	for _, f := range fields {
+		f.Key = prefix + f.Key
+		if f.Kind == GroupKind {
+			result = flatten(result, f.Key+".", f.AsGroup())
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// withFields returns a new logger with additional fields added to the
+// current group of the receiver (if any).
+func (log *logger) withFields(add []Field) *logger {
+	entry := log.clone(log.Context, log.fields)
+	entry.fields = withFieldsIn(log.fields, log.group, add)
+	return entry
+}
+
+// withFieldsIn returns a copy of a slice of Fields with additional Fields
+// added to the group identified by a path (of group names).  The group, and
+// any group containing it, is added if not present; a non-group Field with
+// the same key as a group in the path is replaced by the group.
+func withFieldsIn(fields []Field, path []string, add []Field) []Field {
+	if len(path) == 0 || len(add) == 0 {
+		return withFields(fields, add...)
+	}
+
+	var members []Field
+	for _, f := range fields {
+		if f.Key == path[0] && f.Kind == GroupKind {
+			members = f.AsGroup()
+			break
+		}
+	}
+
+	result := make([]Field, len(fields), len(fields)+1)
+	copy(result, fields)
+	return replaceField(result, Group(path[0], withFieldsIn(members, path[1:], add)...))
+}
+
+// hasGroup returns true if any of a slice of fields is a Group.
+func hasGroup(fields []Field) bool {
+	for _, f := range fields {
+		if f.Kind == GroupKind {
+			return true
+		}
+	}
+	return false
+}
+
+// WithGroup returns a new `Entry` with a specified group.  Any fields
+// subsequently added to the entry are added to the group.  If the entry
+// already has a group, the new group is nested within it.
+//
+// Groups are preserved in any entry initialised from the returned entry,
+// e.g. using `WithContext()` or `NewEntry()`.
+//
+// Adapters implementing `FieldAdapter` receive each group as a `Group`
+// field, allowing (e.g.) nested objects to be emitted.  Other adapters
+// receive the fields in a group with keys qualified by the group name,
+// separated by '.' (e.g. "db.id").
+//
+// A group with no fields is omitted.  Specifying an empty name returns
+// the receiver.
+func (log *logger) WithGroup(name string) Entry {
+	if name == "" {
+		return log
+	}
+
+	entry := log.clone(log.Context, log.fields)
+	entry.group = append(log.group[:len(log.group):len(log.group)], name)
+	return entry
+}
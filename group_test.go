@@ -0,0 +1,193 @@
+package unilog
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestWithGroup(t *testing.T) {
+	// ARRANGE
+	root := UsingAdapter(context.Background(), nil).NewEntry().WithField("id", 1)
+
+	testcases := []struct {
+		name    string
+		entry   Entry
+		flat    map[string]any
+		grouped []Field
+	}{
+		{name: "no group",
+			entry:   root.WithField("name", "a"),
+			flat:    map[string]any{"id": 1, "name": "a"},
+			grouped: []Field{Any("id", 1), Any("name", "a")},
+		},
+		{name: "group",
+			entry:   root.WithGroup("db").WithField("id", 2).WithValues("name", "b"),
+			flat:    map[string]any{"id": 1, "db.id": 2, "db.name": "b"},
+			grouped: []Field{Any("id", 1), Group("db", Any("id", 2), Any("name", "b"))},
+		},
+		{name: "nested groups",
+			entry:   root.WithGroup("svc").WithField("name", "a").WithGroup("db").WithFields(map[string]any{"id": 2}),
+			flat:    map[string]any{"id": 1, "svc.name": "a", "svc.db.id": 2},
+			grouped: []Field{Any("id", 1), Group("svc", Any("name", "a"), Group("db", Any("id", 2)))},
+		},
+		{name: "empty group",
+			entry:   root.WithGroup("db"),
+			flat:    map[string]any{"id": 1},
+			grouped: []Field{Any("id", 1)},
+		},
+		{name: "empty group name",
+			entry:   root.WithGroup("").WithField("name", "a"),
+			flat:    map[string]any{"id": 1, "name": "a"},
+			grouped: []Field{Any("id", 1), Any("name", "a")},
+		},
+		{name: "group field",
+			entry:   root.With(Group("db", String("name", "b"), Group("conn", Int64("port", 5432)))),
+			flat:    map[string]any{"id": 1, "db.name": "b", "db.conn.port": int64(5432)},
+			grouped: []Field{Any("id", 1), Group("db", String("name", "b"), Group("conn", Int64("port", 5432)))},
+		},
+		{name: "group field in group",
+			entry:   root.WithGroup("svc").With(Group("db", String("name", "b"))),
+			flat:    map[string]any{"id": 1, "svc.db.name": "b"},
+			grouped: []Field{Any("id", 1), Group("svc", Group("db", String("name", "b")))},
+		},
+		{name: "preserved by NewEntry",
+			entry:   root.WithGroup("db").(Logger).NewEntry().WithField("id", 2),
+			flat:    map[string]any{"id": 1, "db.id": 2},
+			grouped: []Field{Any("id", 1), Group("db", Any("id", 2))},
+		},
+		{name: "preserved by WithContext",
+			entry:   root.WithGroup("db").WithContext(context.Background()).WithField("id", 2),
+			flat:    map[string]any{"id": 1, "db.id": 2},
+			grouped: []Field{Any("id", 1), Group("db", Any("id", 2))},
+		},
+		{name: "dotted key not in a group",
+			entry:   root.WithGroup("db").WithField("user.name", "a"),
+			flat:    map[string]any{"id": 1, "db.user.name": "a"},
+			grouped: []Field{Any("id", 1), Group("db", Any("user.name", "a"))},
+		},
+		{name: "dotted key not absorbed by group",
+			entry:   root.WithField("db.host", "h").WithGroup("db").WithField("id", 2),
+			flat:    map[string]any{"id": 1, "db.host": "h", "db.id": 2},
+			grouped: []Field{Any("id", 1), Any("db.host", "h"), Group("db", Any("id", 2))},
+		},
+		{name: "field replaced by group with same key",
+			entry:   root.WithField("db", "x").WithGroup("db").WithField("id", 2),
+			flat:    map[string]any{"id": 1, "db.id": 2},
+			grouped: []Field{Any("id", 1), Group("db", Any("id", 2))},
+		},
+		{name: "group replaced by field with same key",
+			entry:   root.With(Group("db", Int64("id", 2))).WithField("db", "x"),
+			flat:    map[string]any{"id": 1, "db": "x"},
+			grouped: []Field{Any("id", 1), Any("db", "x")},
+		},
+		{name: "group fields merged",
+			entry:   root.With(Group("db", Int64("id", 2))).WithGroup("db").WithField("name", "b"),
+			flat:    map[string]any{"id": 1, "db.id": int64(2), "db.name": "b"},
+			grouped: []Field{Any("id", 1), Group("db", Int64("id", 2), Any("name", "b"))},
+		},
+		{name: "empty group with no fields added",
+			entry:   root.WithGroup("db").WithFields(nil),
+			flat:    map[string]any{"id": 1},
+			grouped: []Field{Any("id", 1)},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("adapter", func(t *testing.T) {
+				// ARRANGE
+				adapter := newRecordingAdapter()
+				tc.entry.(*logger).Adapter = adapter
+
+				// ACT
+				tc.entry.Info("test")
+
+				// ASSERT
+				wanted := tc.flat
+				got := adapter.last().fields
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+				}
+			})
+
+			t.Run("field adapter", func(t *testing.T) {
+				// ARRANGE
+				adapter := &fieldAdapter{level: Trace}
+				tc.entry.(*logger).Adapter = adapter
+
+				// ACT
+				tc.entry.Info("test")
+
+				// ASSERT
+				wanted := tc.grouped
+				got := adapter.fields
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+				}
+			})
+		})
+	}
+}
+
+func TestWithGroupEnrichment(t *testing.T) {
+	// ARRANGE
+	oef := enrichmentFuncs
+	defer func() { enrichmentFuncs = oef }()
+	RegisterEnrichment(func(ctx context.Context, e Enricher) Entry {
+		return e.WithField("request-id", "abc")
+	})
+
+	adapter := newRecordingAdapter()
+	entry := UsingAdapter(context.Background(), adapter).NewEntry().WithGroup("db")
+
+	// ACT
+	entry.WithContext(context.Background()).WithField("id", 1).Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"request-id": "abc", "db.id": 1}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	testcases := []struct {
+		name   string
+		fields []Field
+		result []Field
+	}{
+		{name: "no groups", fields: []Field{String("a", "1")}, result: []Field{String("a", "1")}},
+		{name: "groups", fields: []Field{String("a", "1"), Group("g", String("b", "2"), Group("h", String("c", "3")))}, result: []Field{String("a", "1"), String("g.b", "2"), String("g.h.c", "3")}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got := Flatten(tc.fields)
+
+			// ASSERT
+			wanted := tc.result
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestRedactionInGroup(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).
+		WithRedactor(NewRedactor(RedactNames(Mask(), "password"))).
+		NewEntry()
+
+	// ACT
+	sut.WithGroup("db").WithField("password", "secret").Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"db.password": "********"}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
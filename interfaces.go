@@ -8,10 +8,13 @@ type Emitter interface {
 	Emit(Level, string)
 }
 
-// Enricher is in interface that provides a single function for enriching
-// a log entry with an additional field having a specified name and value.
+// Enricher is in interface that provides functions for enriching a log
+// entry with one or more additional fields.
 type Enricher interface {
-	WithField(string, any) Entry
+	WithField(string, any) Entry     // WithField returns a new Entry with the named value added
+	WithFields(map[string]any) Entry // WithFields returns a new Entry with each named value in a map added
+	With(fields ...Field) Entry      // With returns a new Entry with the specified typed fields added
+	WithValues(args ...any) Entry    // WithValues returns a new Entry with fields specified as key/value pairs and/or typed Fields added
 }
 
 // Adapter is an interface that mediates between the abstract Logger and
@@ -24,12 +27,38 @@ type Adapter interface {
 	WithField(string, any) Adapter
 }
 
+// LevelEnabler is an optional interface that may be implemented by an
+// Adapter that does not emit entries at all levels.  When an Adapter
+// implements LevelEnabler, a Logger does not prepare (e.g. format, enrich
+// or redact) any entry at a level that is not enabled.
+type LevelEnabler interface {
+	Enabled(Level) bool
+}
+
+// FieldAdapter is an optional interface that may be implemented by an
+// Adapter able to consume typed Fields.  When an Adapter implements
+// FieldAdapter, a Logger emits entries by calling EmitFields with the
+// fields of the entry, rather than adding each field to the Adapter using
+// WithField and calling Emit.
+//
+// The slice of Fields must not be modified or retained by the Adapter.
+type FieldAdapter interface {
+	Adapter
+	EmitFields(Level, string, []Field)
+}
+
 // Logger is the interface used by applications and modules to initialise
 // log entries.  Applications should normally initialise a Logger with a
 // desired Adapter, passing the Logger to packages that support unilog.
 type Logger interface {
-	WithContext(context.Context) Entry // WithContext returns an Entry encapsulating the specific Context
-	NewEntry() Entry                   // Returns a new Entry encapsulating the Context supplied when the Logger was initialised
+	WithContext(context.Context) Entry      // WithContext returns an Entry encapsulating the specific Context
+	NewEntry() Entry                        // Returns a new Entry encapsulating the Context supplied when the Logger was initialised
+	WithExitBehaviour(ExitBehaviour) Logger // WithExitBehaviour returns a new Logger performing the specified ExitBehaviour following any Fatal entry
+	WithRedactor(*Redactor) Logger          // WithRedactor returns a new Logger applying the specified Redactor to the fields of any entry emitted
+	WithScrubber(*Scrubber) Logger          // WithScrubber returns a new Logger applying the specified Scrubber to the message of any entry emitted
+	WithLevel(Leveler) Logger               // WithLevel returns a new Logger emitting only entries at a level enabled by the specified Level or LevelVar
+	WithLevels(*Levels) Logger              // WithLevels returns a new Logger using the specified Levels to determine the level of entries emitted
+	Named(name string) Logger               // Named returns a new Logger with the specified name appended to the name of the Logger
 }
 
 // Entry is the interface for an individual log entry.  An Entry is an Emitter
@@ -44,19 +73,29 @@ type Logger interface {
 // from the logging context by registered enrichment functions.
 type Entry interface {
 	Emitter
-	Debug(s string)                         // Debug emits a Debug level log message
-	Debugf(format string, args ...any)      // Debugf emits a Debug level log message using a specified format string and args
-	Error(err any)                          // Error emits an Error level log message consisting of err
-	Errorf(format string, args ...any)      // Errorf emits an Error level log message using a specified format string and args
-	Fatal(s string)                         // Fatal emits a Fatal level log message then calls os.Exit(1)
-	Fatalf(format string, args ...any)      // Fatalf emits a Fatal level log message using a specified format string and args, then calls os.Exit(1)
-	FatalError(err error)                   // FatalError emits a Fatal level log message consisting of err.Error() then calls os.Exit(1)
-	Info(s string)                          // Info emits an Info level log message
-	Infof(format string, args ...any)       // Infof emits an Info level log message using a specified format string and args
-	Trace(s string)                         // Trace emits a Trace level log message
-	Tracef(format string, args ...any)      // Tracef emits a Trace level log message using a specified format string and args
-	Warn(s string)                          // Warn emits a Warn level log message
-	Warnf(format string, args ...any)       // Warnf emits a Warn level log message using a specified format string and args
-	WithContext(context.Context) Entry      // WithContext returns a new Entry encapsulating the specified Context
-	WithField(name string, value any) Entry // WithField returns a new Entry with the named value added (a one-off enrichment)
+	BeginScope(name string) *Scope                      // BeginScope returns a new Scope accumulating fields to be emitted in a single entry when the Scope ends
+	Debug(s string)                                     // Debug emits a Debug level log message
+	Debugf(format string, args ...any)                  // Debugf emits a Debug level log message using a specified format string and args
+	Error(err any)                                      // Error emits an Error level log message consisting of err
+	Errorf(format string, args ...any)                  // Errorf emits an Error level log message using a specified format string and args
+	Fatal(s string)                                     // Fatal emits a Fatal level log message then performs the exit behaviour of the Logger (by default, calling ExitFn(1))
+	Fatalf(format string, args ...any)                  // Fatalf emits a Fatal level log message using a specified format string and args, then performs the exit behaviour of the Logger
+	FatalError(err error)                               // FatalError emits a Fatal level log message consisting of err.Error() then performs the exit behaviour of the Logger
+	Info(s string)                                      // Info emits an Info level log message
+	Infof(format string, args ...any)                   // Infof emits an Info level log message using a specified format string and args
+	Trace(s string)                                     // Trace emits a Trace level log message
+	Panic(s string)                                     // Panic emits a Panic level log message then panics with the message
+	Panicf(format string, args ...any)                  // Panicf emits a Panic level log message using a specified format string and args, then panics with the message
+	PanicError(err error)                               // PanicError emits a Panic level log message consisting of err.Error() then panics with err
+	Recover(opts ...RecoverOption)                      // Recover (when deferred) recovers from any panic, emitting an entry with the recovered value and stack
+	Timed(msg string, opts ...TimedOption) func(*error) // Timed returns a func emitting a completion entry with the duration of an operation
+	Tracef(format string, args ...any)                  // Tracef emits a Trace level log message using a specified format string and args
+	Warn(s string)                                      // Warn emits a Warn level log message
+	Warnf(format string, args ...any)                   // Warnf emits a Warn level log message using a specified format string and args
+	WithContext(context.Context) Entry                  // WithContext returns a new Entry encapsulating the specified Context
+	WithField(name string, value any) Entry             // WithField returns a new Entry with the named value added (a one-off enrichment)
+	WithFields(map[string]any) Entry                    // WithFields returns a new Entry with each named value in a map added
+	With(fields ...Field) Entry                         // With returns a new Entry with the specified typed fields added
+	WithValues(args ...any) Entry                       // WithValues returns a new Entry with fields specified as key/value pairs and/or typed Fields added
+	WithGroup(name string) Entry                        // WithGroup returns a new Entry to which any subsequent fields are added in the named group
 }
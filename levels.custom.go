@@ -0,0 +1,128 @@
+package unilog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// firstCustomLevel is the value of the first custom level registered.
+const firstCustomLevel Level = 100
+
+// customLevel describes a custom level.
+type customLevel struct {
+	name string
+	rank float64
+}
+
+var (
+	customLevelsMu sync.Mutex   // serialises registration of custom levels
+	customLevels   atomic.Value // map[Level]customLevel; replaced (not modified) by each registration
+)
+
+func init() {
+	customLevels.Store(map[Level]customLevel{})
+}
+
+// RegisterLevel registers a custom level with a specified name, ranked as
+// more severe than a specified (built-in or custom) level and less severe
+// than any level that is more severe than that level.  e.g. to register
+// Notice and Critical levels:
+//
+//	Notice, _ = unilog.RegisterLevel("Notice", unilog.Info)     // between Info and Warn
+//	Critical, _ = unilog.RegisterLevel("Critical", unilog.Error) // between Error and Fatal
+//
+// A custom level may be used anywhere a built-in level may be used, e.g. with
+// `Emit()`, `WithLevel()` or `Levels`, and is identified by its name in
+// `ParseLevel()` (case-insensitive).  Adapters map custom levels to the
+// severities of a logging package using a `SeverityMap`.
+//
+// Custom levels should be registered during initialisation, before any
+// entries are emitted.  An error is returned if the name is empty or is
+// already the name of a level, or if the specified level is not valid.
+func RegisterLevel(name string, moreSevereThan Level) (Level, error) {
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+
+	if name == "" {
+		return 0, fmt.Errorf("unilog: invalid level name: %q", name)
+	}
+	if _, err := ParseLevel(name); err == nil {
+		return 0, fmt.Errorf("unilog: level already registered: %q", name)
+	}
+	if !moreSevereThan.valid() {
+		return 0, fmt.Errorf("unilog: invalid level: %v", moreSevereThan)
+	}
+
+	// the new level is ranked mid-way between the specified level and the
+	// next more severe level (if any)
+	base := moreSevereThan.rank()
+	rank := base - 1
+	found := false
+	for _, other := range allLevels() {
+		if r := other.rank(); r < base && (!found || r > rank) {
+			rank = r
+			found = true
+		}
+	}
+	if found {
+		rank = (rank + base) / 2
+	}
+
+	current := customLevels.Load().(map[Level]customLevel)
+	levels := make(map[Level]customLevel, len(current)+1)
+	for k, v := range current {
+		levels[k] = v
+	}
+	level := firstCustomLevel + Level(len(current))
+	levels[level] = customLevel{name: name, rank: rank}
+	customLevels.Store(levels)
+
+	return level, nil
+}
+
+// allLevels returns all built-in and custom levels.
+func allLevels() []Level {
+	custom := customLevels.Load().(map[Level]customLevel)
+	levels := make([]Level, 0, int(Trace-Panic)+1+len(custom))
+	for lv := Panic; lv <= Trace; lv++ {
+		levels = append(levels, lv)
+	}
+	for lv := range custom {
+		levels = append(levels, lv)
+	}
+	return levels
+}
+
+// builtin returns true if the level is a built-in level.
+func (lv Level) builtin() bool {
+	return lv >= Panic && lv <= Trace
+}
+
+// custom returns the custom level registered for the level, if any.
+func (lv Level) custom() (customLevel, bool) {
+	cl, ok := customLevels.Load().(map[Level]customLevel)[lv]
+	return cl, ok
+}
+
+// rank returns the severity rank of the level; a lower rank is more severe.
+// The rank of a built-in (or invalid) level is its value.
+func (lv Level) rank() float64 {
+	if !lv.builtin() {
+		if cl, ok := lv.custom(); ok {
+			return cl.rank
+		}
+	}
+	return float64(lv)
+}
+
+// parseCustomLevel returns the custom level with a specified name (lowercase).
+func parseCustomLevel(name string) (Level, bool) {
+	for lv, cl := range customLevels.Load().(map[Level]customLevel) {
+		if strings.ToLower(cl.name) == name {
+			return lv, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,133 @@
+package unilog
+
+import (
+	"context"
+	"testing"
+)
+
+// withCustomLevels calls a func with the custom levels registered by the func
+// removed when it returns.
+func withCustomLevels(t *testing.T, fn func()) {
+	t.Helper()
+	og := customLevels.Load()
+	defer customLevels.Store(og)
+	fn()
+}
+
+func TestRegisterLevel(t *testing.T) {
+	withCustomLevels(t, func() {
+		// ARRANGE
+		notice, err := RegisterLevel("Notice", Info)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		critical, _ := RegisterLevel("Critical", Error)
+		verbose, _ := RegisterLevel("Verbose", Trace)
+		loud, _ := RegisterLevel("Loud", notice)
+
+		t.Run("string", func(t *testing.T) {
+			wanted := "Notice"
+			got := notice.String()
+			if wanted != got {
+				t.Errorf("wanted %q, got %q", wanted, got)
+			}
+		})
+
+		t.Run("at least", func(t *testing.T) {
+			if !critical.AtLeast(Error) || critical.AtLeast(Fatal) || notice.AtLeast(Warn) || !notice.AtLeast(Info) {
+				t.Errorf("unexpected severity of custom levels")
+			}
+		})
+
+		t.Run("parse", func(t *testing.T) {
+			wanted := critical
+			got, err := ParseLevel("CRITICAL")
+			if err != nil || wanted != got {
+				t.Errorf("wanted %v, got %v (%v)", wanted, got, err)
+			}
+		})
+
+		t.Run("marshal", func(t *testing.T) {
+			wanted := "notice"
+			got, err := notice.MarshalText()
+			if err != nil || wanted != string(got) {
+				t.Errorf("wanted %q, got %q (%v)", wanted, got, err)
+			}
+		})
+
+		testcases := []struct {
+			name      string
+			level     Level
+			threshold Level
+			result    bool
+		}{
+			{name: "notice at info", level: notice, threshold: Info, result: true},
+			{name: "notice at notice", level: notice, threshold: notice, result: true},
+			{name: "notice at warn", level: notice, threshold: Warn, result: false},
+			{name: "info at notice", level: Info, threshold: notice, result: false},
+			{name: "warn at notice", level: Warn, threshold: notice, result: true},
+			{name: "loud at notice", level: loud, threshold: notice, result: true},
+			{name: "loud at warn", level: loud, threshold: Warn, result: false},
+			{name: "critical at error", level: critical, threshold: Error, result: true},
+			{name: "fatal at critical", level: Fatal, threshold: critical, result: true},
+			{name: "error at critical", level: Error, threshold: critical, result: false},
+			{name: "verbose at trace", level: verbose, threshold: Trace, result: true},
+			{name: "trace at verbose", level: Trace, threshold: verbose, result: false},
+			{name: "verbose at debug", level: verbose, threshold: Debug, result: false},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				wanted := tc.result
+				got := tc.level.enabledAt(tc.threshold)
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+		}
+
+		t.Run("emitted", func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			sut := UsingAdapter(context.Background(), adapter).WithLevel(notice).NewEntry()
+
+			// ACT
+			sut.Emit(Info, "not emitted")
+			sut.Emit(notice, "emitted")
+
+			// ASSERT
+			wanted := "NOTICE: emitted"
+			got := levelPrefix(adapter.last().level) + ": " + adapter.last().s
+			if len(*adapter.entries) != 1 || wanted != got {
+				t.Errorf("wanted %q, got %q (%d entries)", wanted, got, len(*adapter.entries))
+			}
+		})
+	})
+}
+
+func TestRegisterLevelErrors(t *testing.T) {
+	withCustomLevels(t, func() {
+		// ARRANGE
+		_, _ = RegisterLevel("Notice", Info)
+
+		testcases := []struct {
+			name           string
+			moreSevereThan Level
+		}{
+			{name: ""},
+			{name: "info", moreSevereThan: Debug},
+			{name: "NOTICE", moreSevereThan: Debug},
+			{name: "Critical", moreSevereThan: Level(42)},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				// ACT
+				_, err := RegisterLevel(tc.name, tc.moreSevereThan)
+
+				// ASSERT
+				if err == nil {
+					t.Error("wanted error, got nil")
+				}
+			})
+		}
+	})
+}
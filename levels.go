@@ -1,14 +1,29 @@
 package unilog
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Level identifies the logging level for a particular log entry.
-// The possible values for `Level` are modelled on `logrus`,
-// though `Panic` is not supported (`Fatal` is the most severe).
+// The possible values for `Level` are modelled on `logrus`; `Panic` is
+// the most severe.  Additional levels may be registered using
+// `RegisterLevel()`.
+//
+// Adapters for logging packages with no panic level should map `Panic` to
+// the most severe level that does not itself panic or terminate the process
+// (e.g. an "error" or "critical" level); the `Logger` panics after the entry
+// has been emitted.  Similarly, an adapter should not map `Fatal` to a level
+// that terminates the process, since the `Logger` performs its own exit
+// behaviour.  A `SeverityMap` may be used to map any level (including custom
+// levels) to the severities of a logging package.
 type Level int
 
 const (
-	Fatal Level = iota // logging at this level will terminate the process after emitting the log entry (without returning any error)
+	Panic Level = iota - 1 // logging at this level will panic after emitting the log entry (the panic may be recovered)
+	Fatal                  // logging at this level will terminate the process after emitting the log entry (without returning any error)
 	Error
 	Warn
 	Info
@@ -18,6 +33,8 @@ const (
 
 func (lv Level) String() string {
 	switch lv {
+	case Panic:
+		return "Panic"
 	case Fatal:
 		return "Fatal"
 	case Error:
@@ -31,6 +48,114 @@ func (lv Level) String() string {
 	case Trace:
 		return "Trace"
 	default:
+		if cl, ok := lv.custom(); ok {
+			return cl.name
+		}
 		return fmt.Sprintf("<invalid (%d)>", lv)
 	}
 }
+
+// enabledAt returns true if an entry at the level of the receiver is
+// emitted by a logger with a specified level, i.e. if the receiver is the
+// same as or more severe than the specified level.
+func (lv Level) enabledAt(level Level) bool {
+	if lv.builtin() && level.builtin() {
+		return lv <= level
+	}
+	return lv.rank() <= level.rank()
+}
+
+// AtLeast returns true if the receiver is the same as or more severe than a
+// specified level, e.g. `level.AtLeast(unilog.Error)` is true for `Error`,
+// `Fatal`, `Panic` and any custom level more severe than `Error`.
+func (lv Level) AtLeast(level Level) bool {
+	return lv.enabledAt(level)
+}
+
+// levelAliases maps alternative names (lowercase) to the level they identify.
+var levelAliases = map[string]Level{
+	"err":         Error,
+	"warning":     Warn,
+	"information": Info,
+}
+
+// ParseLevel returns the Level identified by a specified string, which may be:
+//
+//   - the name of a built-in or custom level (e.g. "info"; case-insensitive);
+//   - an alias of a level: "err", "warning" or "information";
+//   - the numeric value of a level (e.g. "3" for `Info`).
+//
+// An error is returned if the string does not identify a valid level.
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if lv, ok := levelAliases[name]; ok {
+		return lv, nil
+	}
+
+	if n, err := strconv.Atoi(name); err == nil {
+		if lv := Level(n); lv.valid() {
+			return lv, nil
+		}
+		return 0, fmt.Errorf("unilog: invalid level: %q", s)
+	}
+
+	for lv := Panic; lv <= Trace; lv++ {
+		if name == strings.ToLower(lv.String()) {
+			return lv, nil
+		}
+	}
+	if lv, ok := parseCustomLevel(name); ok {
+		return lv, nil
+	}
+	return 0, fmt.Errorf("unilog: invalid level: %q", s)
+}
+
+// valid returns true if the level is a built-in or custom Level.
+func (lv Level) valid() bool {
+	if lv.builtin() {
+		return true
+	}
+	_, ok := lv.custom()
+	return ok
+}
+
+// Level returns the receiver, implementing `Leveler`.
+func (lv Level) Level() Level {
+	return lv
+}
+
+// MarshalText implements `encoding.TextMarshaler`, returning the name of
+// the level in lowercase (e.g. "info").  An error is returned for an
+// invalid level.
+func (lv Level) MarshalText() ([]byte, error) {
+	if !lv.valid() {
+		return nil, fmt.Errorf("unilog: invalid level: %d", int(lv))
+	}
+	return []byte(strings.ToLower(lv.String())), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`, setting the level
+// identified by the text (see `ParseLevel()`).
+func (lv *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*lv = level
+	return nil
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`, setting the level identified
+// by either a JSON string (see `ParseLevel()`) or number.  A Level is
+// marshalled to JSON as a string (see `MarshalText()`).
+func (lv *Level) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("unilog: invalid level: %s", data)
+		}
+		s = strconv.Itoa(n)
+	}
+	return lv.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,235 @@
+package unilog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LevelSetting describes the level set for a name in a `Levels`, as
+// exchanged (in JSON) with a `LevelsHandler`.
+//
+// When listing settings or getting the level for a name, Expires is the
+// time at which a temporary level will revert (if any).  When setting a
+// level, TTL (if specified) is the duration for which the level is to be
+// set, e.g. "15m" (see `time.ParseDuration()`).
+type LevelSetting struct {
+	Name    string     `json:"name"`
+	Level   string     `json:"level"`
+	TTL     string     `json:"ttl,omitempty"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// LevelsHandler returns an `http.Handler` allowing the settings in a `Levels`
+// to be listed and modified at runtime, e.g. to raise the level of a running
+// service during an incident, without a restart or re-deployment:
+//
+//	GET                  lists all settings
+//	GET    ?name=<name>  returns the effective level for a name
+//	PUT                  sets the level for a name (JSON body)
+//	DELETE ?name=<name>  removes the setting for a name
+//
+// The root level is identified by an empty name.  The body of a PUT request
+// is a `LevelSetting` (of at most 4KB); if a TTL is specified the setting
+// reverts to that in effect before the level was set when the TTL expires
+// (see `SetFor()`):
+//
+//	{"name": "payments.db", "level": "debug", "ttl": "15m"}
+//
+// Levels are identified by name (case-insensitive).  Responses are JSON;
+// a GET of all settings returns a list of `LevelSetting`, sorted by name.
+//
+// The handler provides no authentication or authorization; it is intended
+// to be mounted on an admin (or otherwise protected) endpoint.
+func LevelsHandler(levels *Levels) http.Handler {
+	return &levelsHandler{levels: levels}
+}
+
+// maxSettingSize is the maximum size (in bytes) of the body of a PUT request
+// to a `LevelsHandler`.
+const maxSettingSize = 4096
+
+// levelsHandler is the `http.Handler` returned by `LevelsHandler()`.
+type levelsHandler struct {
+	levels *Levels
+}
+
+// ServeHTTP implements `http.Handler`.
+func (h *levelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	_, named := query["name"]
+	name := query.Get("name")
+
+	switch {
+	case r.Method == http.MethodGet && named:
+		h.writeJSON(w, http.StatusOK, h.setting(name))
+
+	case r.Method == http.MethodGet:
+		settings := h.levels.Settings()
+		result := make([]LevelSetting, 0, len(settings))
+		for name := range settings {
+			result = append(result, h.setting(name))
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+		h.writeJSON(w, http.StatusOK, result)
+
+	case r.Method == http.MethodPut:
+		h.put(w, r)
+
+	case r.Method == http.MethodDelete && named:
+		h.levels.Unset(name)
+		h.writeJSON(w, http.StatusOK, h.setting(name))
+
+	case r.Method == http.MethodDelete:
+		http.Error(w, "name is required", http.StatusBadRequest)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// put sets the level for a name from the `LevelSetting` in the body of a
+// request.  A body larger than maxSettingSize, or with any member that is
+// not a field of a LevelSetting, is rejected.
+func (h *levelsHandler) put(w http.ResponseWriter, r *http.Request) {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSettingSize))
+	dec.DisallowUnknownFields()
+
+	var req LevelSetting
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.TTL == "" {
+		h.levels.Set(req.Name, level)
+		h.writeJSON(w, http.StatusOK, h.setting(req.Name))
+		return
+	}
+
+	ttl, err := time.ParseDuration(req.TTL)
+	if err != nil || ttl <= 0 {
+		http.Error(w, "invalid ttl: "+req.TTL, http.StatusBadRequest)
+		return
+	}
+	h.levels.SetFor(req.Name, level, ttl)
+	h.writeJSON(w, http.StatusOK, h.setting(req.Name))
+}
+
+// setting returns the `LevelSetting` describing the effective level for
+// a name.
+func (h *levelsHandler) setting(name string) LevelSetting {
+	result := LevelSetting{Name: name, Level: h.levels.Level(name).String()}
+	if expires, ok := h.levels.Expires(name); ok {
+		result.Expires = &expires
+	}
+	return result
+}
+
+// writeJSON writes a response with a specified status and JSON body.
+func (h *levelsHandler) writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+const (
+	// DebugLevelHeader is the name of the request header identifying the
+	// level to be set for a request by `DebugLevelMiddleware()`.
+	DebugLevelHeader = "X-Debug-Level"
+
+	// DebugTokenHeader is the name of the request header providing a token
+	// authorizing the level identified by `DebugLevelHeader`.
+	DebugTokenHeader = "X-Debug-Token"
+)
+
+// DebugAuthorizer is a func determining whether a request is authorized to
+// set a specified level (see `DebugLevelMiddleware()`).
+type DebugAuthorizer func(r *http.Request, level Level) bool
+
+// DebugLevelMiddleware returns HTTP middleware setting the level for entries
+// initialised from the context of a request (see `ContextWithLevel()`) when
+// the request has a `DebugLevelHeader` identifying a level (by name, case-
+// insensitive) and a specified `DebugAuthorizer` authorizes it, e.g.:
+//
+//	X-Debug-Level: trace
+//	X-Debug-Token: <token>
+//
+// Requests without the header, or with an invalid or unauthorized level,
+// are served with their context unmodified.
+func DebugLevelMiddleware(authorize DebugAuthorizer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if name := r.Header.Get(DebugLevelHeader); name != "" {
+				if level, err := ParseLevel(name); err == nil && authorize(r, level) {
+					r = r.WithContext(ContextWithLevel(r.Context(), level))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AllowDebugTokens returns a `DebugAuthorizer` authorizing requests with a
+// `DebugTokenHeader` matching any of a specified list of tokens.
+func AllowDebugTokens(tokens ...string) DebugAuthorizer {
+	return func(r *http.Request, _ Level) bool {
+		token := r.Header.Get(DebugTokenHeader)
+		if token == "" {
+			return false
+		}
+		for _, t := range tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SignedDebugTokens returns a `DebugAuthorizer` authorizing requests with a
+// `DebugTokenHeader` providing an unexpired token, signed using a specified
+// key, for the requested level.  Tokens are obtained using `SignDebugToken()`.
+func SignedDebugTokens(key []byte) DebugAuthorizer {
+	return func(r *http.Request, level Level) bool {
+		token := r.Header.Get(DebugTokenHeader)
+
+		i := strings.IndexByte(token, '.')
+		if i == -1 {
+			return false
+		}
+		expires, err := strconv.ParseInt(token[:i], 10, 64)
+		if err != nil || time.Now().Unix() > expires {
+			return false
+		}
+
+		wanted := SignDebugToken(key, level, time.Unix(expires, 0))
+		return hmac.Equal([]byte(token), []byte(wanted))
+	}
+}
+
+// SignDebugToken returns a token authorizing a specified level until a
+// specified time, signed (using HMAC-SHA256) with a specified key, for use
+// with `SignedDebugTokens()`.  The token has the form "<expires>.<signature>",
+// where <expires> is a Unix time (in seconds).
+func SignDebugToken(key []byte, level Level, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.ToLower(level.String()) + "." + exp))
+	return exp + "." + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,283 @@
+package unilog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevelsHandler(t *testing.T) {
+	testcases := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		status   int
+		response string
+		settings map[string]Level
+	}{
+		{name: "get all",
+			method:   http.MethodGet,
+			target:   "/",
+			status:   http.StatusOK,
+			response: `[{"name":"","level":"Info"},{"name":"payments","level":"Warn"}]`,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "get root",
+			method:   http.MethodGet,
+			target:   "/?name=",
+			status:   http.StatusOK,
+			response: `{"name":"","level":"Info"}`,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "get name (inherited)",
+			method:   http.MethodGet,
+			target:   "/?name=payments.db",
+			status:   http.StatusOK,
+			response: `{"name":"payments.db","level":"Warn"}`,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "put root",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"level":"error"}`,
+			status:   http.StatusOK,
+			response: `{"name":"","level":"Error"}`,
+			settings: map[string]Level{"": Error, "payments": Warn},
+		},
+		{name: "put name",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"name":"payments.db","level":"DEBUG"}`,
+			status:   http.StatusOK,
+			response: `{"name":"payments.db","level":"Debug"}`,
+			settings: map[string]Level{"": Info, "payments": Warn, "payments.db": Debug},
+		},
+		{name: "put invalid body",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"level":`,
+			status:   http.StatusBadRequest,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "put unknown field",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"name":"payments","level":"debug","levle":"trace"}`,
+			status:   http.StatusBadRequest,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "put body too large",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"name":"` + strings.Repeat("a", maxSettingSize) + `","level":"debug"}`,
+			status:   http.StatusBadRequest,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "put invalid level",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"name":"payments","level":"verbose"}`,
+			status:   http.StatusBadRequest,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "put invalid ttl",
+			method:   http.MethodPut,
+			target:   "/",
+			body:     `{"name":"payments","level":"debug","ttl":"soon"}`,
+			status:   http.StatusBadRequest,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "delete name",
+			method:   http.MethodDelete,
+			target:   "/?name=payments",
+			status:   http.StatusOK,
+			response: `{"name":"payments","level":"Info"}`,
+			settings: map[string]Level{"": Info},
+		},
+		{name: "delete without name",
+			method:   http.MethodDelete,
+			target:   "/",
+			status:   http.StatusBadRequest,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "unsupported method",
+			method:   http.MethodPost,
+			target:   "/",
+			status:   http.StatusMethodNotAllowed,
+			settings: map[string]Level{"": Info, "payments": Warn},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			levels := NewLevels(Info)
+			levels.Set("payments", Warn)
+			sut := LevelsHandler(levels)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+
+			// ACT
+			sut.ServeHTTP(rec, req)
+
+			// ASSERT
+			t.Run("status", func(t *testing.T) {
+				wanted := tc.status
+				got := rec.Code
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			if tc.response != "" {
+				t.Run("response", func(t *testing.T) {
+					wanted := tc.response
+					got := strings.TrimSpace(rec.Body.String())
+					if wanted != got {
+						t.Errorf("\nwanted %s\ngot    %s", wanted, got)
+					}
+				})
+			}
+
+			t.Run("settings", func(t *testing.T) {
+				wanted := tc.settings
+				got := levels.Settings()
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+				}
+			})
+		})
+	}
+}
+
+func TestLevelsHandlerWithTTL(t *testing.T) {
+	// ARRANGE
+	ts := &timers{}
+	oaf := afterFunc
+	defer func() { afterFunc = oaf }()
+	afterFunc = ts.afterFunc
+
+	levels := NewLevels(Info)
+	sut := LevelsHandler(levels)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"name":"payments","level":"debug","ttl":"15m"}`))
+
+	// ACT
+	sut.ServeHTTP(rec, req)
+
+	// ASSERT
+	if !strings.Contains(rec.Body.String(), `"expires":`) {
+		t.Errorf("wanted expires in response, got %s", rec.Body.String())
+	}
+
+	if got := levels.Level("payments"); got != Debug {
+		t.Errorf("wanted %v, got %v", Debug, got)
+	}
+
+	ts.expire()
+	if got := levels.Level("payments"); got != Info {
+		t.Errorf("after ttl: wanted %v, got %v", Info, got)
+	}
+}
+
+func TestDebugLevelMiddleware(t *testing.T) {
+	key := []byte("secret")
+
+	testcases := []struct {
+		name      string
+		authorize DebugAuthorizer
+		headers   map[string]string
+		level     Level
+		ok        bool
+	}{
+		{name: "no header",
+			authorize: AllowDebugTokens("token"),
+		},
+		{name: "allow-listed token",
+			authorize: AllowDebugTokens("other", "token"),
+			headers:   map[string]string{DebugLevelHeader: "trace", DebugTokenHeader: "token"},
+			level:     Trace,
+			ok:        true,
+		},
+		{name: "token not allow-listed",
+			authorize: AllowDebugTokens("token"),
+			headers:   map[string]string{DebugLevelHeader: "trace", DebugTokenHeader: "guess"},
+		},
+		{name: "no token",
+			authorize: AllowDebugTokens("token"),
+			headers:   map[string]string{DebugLevelHeader: "trace"},
+		},
+		{name: "invalid level",
+			authorize: AllowDebugTokens("token"),
+			headers:   map[string]string{DebugLevelHeader: "verbose", DebugTokenHeader: "token"},
+		},
+		{name: "signed token",
+			authorize: SignedDebugTokens(key),
+			headers: map[string]string{
+				DebugLevelHeader: "Debug",
+				DebugTokenHeader: SignDebugToken(key, Debug, time.Now().Add(time.Minute)),
+			},
+			level: Debug,
+			ok:    true,
+		},
+		{name: "signed token/different level",
+			authorize: SignedDebugTokens(key),
+			headers: map[string]string{
+				DebugLevelHeader: "trace",
+				DebugTokenHeader: SignDebugToken(key, Debug, time.Now().Add(time.Minute)),
+			},
+		},
+		{name: "signed token/different key",
+			authorize: SignedDebugTokens(key),
+			headers: map[string]string{
+				DebugLevelHeader: "debug",
+				DebugTokenHeader: SignDebugToken([]byte("guess"), Debug, time.Now().Add(time.Minute)),
+			},
+		},
+		{name: "signed token/expired",
+			authorize: SignedDebugTokens(key),
+			headers: map[string]string{
+				DebugLevelHeader: "debug",
+				DebugTokenHeader: SignDebugToken(key, Debug, time.Now().Add(-time.Minute)),
+			},
+		},
+		{name: "signed token/malformed",
+			authorize: SignedDebugTokens(key),
+			headers:   map[string]string{DebugLevelHeader: "debug", DebugTokenHeader: "token"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			var level Level
+			var ok bool
+			next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				level, ok = LevelFromContext(r.Context())
+			})
+			sut := DebugLevelMiddleware(tc.authorize)(next)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			// ACT
+			sut.ServeHTTP(httptest.NewRecorder(), req)
+
+			// ASSERT
+			wanted := tc.ok
+			got := ok
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+			if tc.ok && tc.level != level {
+				t.Errorf("wanted %v, got %v", tc.level, level)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package unilog
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestLevelsString(t *testing.T) {
 	testcases := []struct {
@@ -13,7 +16,8 @@ func TestLevelsString(t *testing.T) {
 		{name: "Warn", level: Warn},
 		{name: "Error", level: Error},
 		{name: "Fatal", level: Fatal},
-		{name: "<invalid (-1)>", level: Level(-1)},
+		{name: "Panic", level: Panic},
+		{name: "<invalid (-2)>", level: Level(-2)},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -28,3 +32,147 @@ func TestLevelsString(t *testing.T) {
 		})
 	}
 }
+
+func TestLevelAtLeast(t *testing.T) {
+	testcases := []struct {
+		level  Level
+		other  Level
+		result bool
+	}{
+		{level: Error, other: Error, result: true},
+		{level: Fatal, other: Error, result: true},
+		{level: Panic, other: Error, result: true},
+		{level: Warn, other: Error},
+		{level: Trace, other: Debug},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.level.String()+"/"+tc.other.String(), func(t *testing.T) {
+			// ACT
+			got := tc.level.AtLeast(tc.other)
+
+			// ASSERT
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	testcases := []struct {
+		name   string
+		result Level
+		err    bool
+	}{
+		{name: "trace", result: Trace},
+		{name: "DEBUG", result: Debug},
+		{name: "Info", result: Info},
+		{name: "warn", result: Warn},
+		{name: "error", result: Error},
+		{name: "fatal", result: Fatal},
+		{name: "panic", result: Panic},
+		{name: " info ", result: Info},
+		{name: "warning", result: Warn},
+		{name: "ERR", result: Error},
+		{name: "information", result: Info},
+		{name: "3", result: Info},
+		{name: "-1", result: Panic},
+		{name: "6", err: true},
+		{name: "verbose", err: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got, err := ParseLevel(tc.name)
+
+			// ASSERT
+			if tc.err != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestLevelMarshalText(t *testing.T) {
+	testcases := []struct {
+		level  Level
+		result string
+		err    bool
+	}{
+		{level: Panic, result: "panic"},
+		{level: Info, result: "info"},
+		{level: Trace, result: "trace"},
+		{level: Level(99), err: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			// ACT
+			text, err := tc.level.MarshalText()
+
+			// ASSERT
+			if tc.err != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			wanted := tc.result
+			got := string(text)
+			if wanted != got {
+				t.Errorf("wanted %q, got %q", wanted, got)
+			}
+		})
+	}
+}
+
+func TestLevelJSON(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		// ACT
+		data, err := json.Marshal(config{Level: Warn})
+
+		// ASSERT
+		wanted := `{"level":"warn"}`
+		got := string(data)
+		if err != nil || wanted != got {
+			t.Errorf("wanted %s, got %s (%v)", wanted, got, err)
+		}
+	})
+
+	testcases := []struct {
+		name   string
+		json   string
+		result Level
+		err    bool
+	}{
+		{name: "name", json: `{"level":"DEBUG"}`, result: Debug},
+		{name: "alias", json: `{"level":"warning"}`, result: Warn},
+		{name: "number", json: `{"level":4}`, result: Debug},
+		{name: "numeric string", json: `{"level":"4"}`, result: Debug},
+		{name: "invalid name", json: `{"level":"verbose"}`, err: true},
+		{name: "invalid number", json: `{"level":42}`, err: true},
+		{name: "invalid type", json: `{"level":true}`, err: true},
+	}
+	for _, tc := range testcases {
+		t.Run("unmarshal/"+tc.name, func(t *testing.T) {
+			// ACT
+			var cfg config
+			err := json.Unmarshal([]byte(tc.json), &cfg)
+
+			// ASSERT
+			if tc.err != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			wanted := tc.result
+			got := cfg.Level
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package unilog
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Leveler is implemented by types providing a Level.  Both `Level` and
+// `*LevelVar` implement Leveler, allowing a Logger to use either a fixed or
+// a variable level (see `WithLevel()`).
+type Leveler interface {
+	Level() Level
+}
+
+// LevelVar is a Level that may be changed (atomically) at any time, with any
+// change effective immediately for all Loggers using it (see `WithLevel()`).
+//
+// A LevelVar implements `flag.Value`, allowing a level to be set directly
+// from a command-line flag:
+//
+//	var level = &unilog.LevelVar{} // Info
+//	flag.Var(level, "log-level", "the level of log entries to be emitted")
+//
+// The zero value of a LevelVar is `Info`.  A LevelVar is safe for concurrent
+// use.
+type LevelVar struct {
+	value int64 // the offset of the level from Info, so that the zero value is Info
+}
+
+// Level returns the current level.
+func (v *LevelVar) Level() Level {
+	return Level(atomic.LoadInt64(&v.value) + int64(Info))
+}
+
+// SetLevel sets the level.
+func (v *LevelVar) SetLevel(level Level) {
+	atomic.StoreInt64(&v.value, int64(level)-int64(Info))
+}
+
+// Set sets the level identified by a specified string (see `ParseLevel()`),
+// implementing `flag.Value`.
+func (v *LevelVar) Set(s string) error {
+	level, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	v.SetLevel(level)
+	return nil
+}
+
+// String returns the name of the current level, implementing `flag.Value`.
+func (v *LevelVar) String() string {
+	if v == nil {
+		return ""
+	}
+	text, err := v.Level().MarshalText()
+	if err != nil {
+		return fmt.Sprintf("LevelVar(%v)", v.Level())
+	}
+	return string(text)
+}
+
+// MarshalText implements `encoding.TextMarshaler` (see `Level.MarshalText()`).
+func (v *LevelVar) MarshalText() ([]byte, error) {
+	return v.Level().MarshalText()
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler` (see `ParseLevel()`).
+func (v *LevelVar) UnmarshalText(text []byte) error {
+	return v.Set(string(text))
+}
@@ -0,0 +1,144 @@
+package unilog
+
+import (
+	"context"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestLevelVar(t *testing.T) {
+	// ARRANGE
+	sut := &LevelVar{}
+
+	// ACT
+	sut.SetLevel(Debug)
+
+	// ASSERT
+	wanted := Debug
+	got := sut.Level()
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
+
+func TestLevelVarZeroValue(t *testing.T) {
+	// ARRANGE
+	sut := &LevelVar{}
+
+	// ACT
+	got := sut.Level()
+
+	// ASSERT
+	wanted := Info
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
+
+func TestLevelVarLevels(t *testing.T) {
+	for _, level := range []Level{Panic, Fatal, Error, Warn, Info, Debug, Trace, Level(100)} {
+		t.Run(level.String(), func(t *testing.T) {
+			// ARRANGE
+			sut := &LevelVar{}
+
+			// ACT
+			sut.SetLevel(level)
+
+			// ASSERT
+			wanted := level
+			got := sut.Level()
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestLevelVarAsFlag(t *testing.T) {
+	testcases := []struct {
+		name   string
+		args   []string
+		result string
+		err    bool
+	}{
+		{name: "default", args: []string{}, result: "info"},
+		{name: "set", args: []string{"-log-level", "debug"}, result: "debug"},
+		{name: "invalid", args: []string{"-log-level", "verbose"}, result: "info", err: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			sut := &LevelVar{}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(sut, "log-level", "the log level")
+
+			// ACT
+			err := fs.Parse(tc.args)
+
+			// ASSERT
+			if tc.err != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			wanted := tc.result
+			got := sut.String()
+			if wanted != got {
+				t.Errorf("wanted %q, got %q", wanted, got)
+			}
+		})
+	}
+}
+
+func TestLevelVarText(t *testing.T) {
+	// ARRANGE
+	sut := &LevelVar{}
+
+	// ACT
+	err := sut.UnmarshalText([]byte("warning"))
+	text, _ := sut.MarshalText()
+
+	// ASSERT
+	wanted := "warn"
+	got := string(text)
+	if err != nil || wanted != got {
+		t.Errorf("wanted %q, got %q (%v)", wanted, got, err)
+	}
+}
+
+func TestLoggerWithLevel(t *testing.T) {
+	// ARRANGE
+	lv := &LevelVar{}
+	lv.SetLevel(Info)
+	adapter := &fieldAdapter{level: Trace}
+	fixed := UsingAdapter(context.Background(), adapter).WithLevel(Warn).NewEntry()
+	variable := UsingAdapter(context.Background(), adapter).WithLevel(lv).NewEntry()
+
+	testcases := []struct {
+		name    string
+		exec    func()
+		emitted int
+	}{
+		{name: "fixed/not enabled", exec: func() { fixed.Info("entry") }, emitted: 0},
+		{name: "fixed/enabled", exec: func() { fixed.Warn("entry") }, emitted: 1},
+		{name: "variable/not enabled", exec: func() { variable.Debug("entry") }, emitted: 0},
+		{name: "variable/enabled", exec: func() { variable.Info("entry") }, emitted: 1},
+		{name: "variable/changed", exec: func() { lv.SetLevel(Debug); variable.Debug("entry") }, emitted: 1},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter.calls = 0
+
+			// ACT
+			tc.exec()
+
+			// ASSERT
+			wanted := tc.emitted
+			got := adapter.calls
+			if wanted != got {
+				t.Errorf("wanted %d, got %d", wanted, got)
+			}
+		})
+	}
+}
@@ -13,30 +13,104 @@ import (
 type logger struct {
 	context.Context
 	Adapter
-	fields map[string]any
+	fields   []Field
+	group    []string // the path of the current group to which fields are added
+	name     string
+	levels   *Levels
+	leveler  Leveler
+	override bool  // true if the level of the logger is overridden by its context
+	level    Level // the level set in the context of the logger (if override is true)
+	recorder *recorder
+	onFatal  ExitBehaviour
+	redactor *Redactor
+	scrubber *Scrubber
 }
 
-// copyFields returns a copy of the fields map, or nil if there are no fields.
-func (log *logger) copyFields() map[string]any {
-	if log.fields == nil {
-		return nil
-	}
+// clone returns a new `logger` with the same configuration as the
+// receiver, encapsulating a specified `Context` and fields.
+//
+// The fields of a logger are never modified once initialised (a new slice
+// is created whenever fields are added) so may be shared between loggers.
+func (log *logger) clone(ctx context.Context, fields []Field) *logger {
+	entry := *log
+	entry.Context = ctx
+	entry.fields = fields
+	return &entry
+}
 
-	fields := make(map[string]any, len(log.fields))
-	for k, v := range log.fields {
-		fields[k] = v
+// enabled returns true if entries at a specified level are emitted by the
+// logger.  A level is enabled if it is enabled by the `Leveler` of the logger
+// (if any), the `Levels` of the logger (for the name of the logger) and the
+// adapter.  If the logger has no
+// `Levels`, or the adapter does not implement `LevelEnabler`, all levels are
+// enabled by that logger or adapter respectively.
+//
+// If the context of the logger has a level (see `ContextWithLevel()`), a
+// level is enabled only if enabled by that level.
+func (log *logger) enabled(level Level) bool {
+	if log.override {
+		return level.enabledAt(log.level)
 	}
-	return fields
+	if log.leveler != nil && !level.enabledAt(log.leveler.Level()) {
+		return false
+	}
+	if log.levels != nil && !level.enabledAt(log.levels.Level(log.name)) {
+		return false
+	}
+	le, ok := log.Adapter.(LevelEnabler)
+	return !ok || le.Enabled(level)
+}
+
+// discards returns true if entries at a specified level are neither emitted
+// nor recorded (see `ContextWithRecorder()`) by the logger.
+func (log *logger) discards(level Level) bool {
+	return log.recorder == nil && !log.enabled(level)
 }
 
 // Emit sends a specified string to the logger with the specified log level.
+//
+// If the level is not enabled and the context of the logger has a recorder
+// (see `ContextWithRecorder()`), the entry is recorded.  If the level is
+// `Error` or more severe, any recorded entries are emitted first.
 func (log *logger) Emit(level Level, s string) {
-	adapter := log.fromContext(log.Context).(*logger).Adapter
+	if !log.enabled(level) {
+		if log.recorder != nil {
+			log.recorder.record(log, level, s)
+		}
+		return
+	}
+	if log.recorder != nil && level.enabledAt(Error) {
+		log.recorder.flush()
+	}
+	log.emit(level, s)
+}
+
+// emit sends a specified string to the adapter of the logger with the
+// specified log level.
+//
+// Any `LogValuer` field values are resolved, then any redaction configured
+// for the logger is applied to the fields of the entry and any scrubber to
+// the string before they are passed to the adapter.
+//
+// If the adapter implements `FieldAdapter` the fields are passed to the
+// adapter as typed `Field`s (with the fields of any group in a `Group`
+// field), otherwise each field is added to the adapter using `WithField()`.
+func (log *logger) emit(level Level, s string) {
+	adapter := log.Adapter
+	if len(enrichmentFuncs) > 0 {
+		adapter = log.fromContext(log.Context).(*logger).Adapter
+	}
+	s = log.scrubber.Scrub(s)
+	fields := log.redactor.redact(resolveFields(log.fields))
 
-	for k, v := range log.fields {
-		adapter = adapter.WithField(k, v)
+	if fa, ok := adapter.(FieldAdapter); ok {
+		fa.EmitFields(level, s, fields)
+		return
 	}
 
+	for _, f := range Flatten(fields) {
+		adapter = adapter.WithField(f.Key, f.Value())
+	}
 	adapter.Emit(level, s)
 }
 
@@ -58,23 +132,50 @@ func (log *logger) entryFromArgs(args ...any) Entry {
 			continue
 		}
 
-		return log.fromContext(ctx)
+		return log.errorEntry(ctx)
 	}
 
 	return log
 }
 
+// errorEntry returns a new entry initialised with the context of an error
+// (see `fromContext()`).  If the context has no recorder (see
+// `ContextWithRecorder()`), any recorder of the receiver is retained, so that
+// entries recorded for the context of the receiver are flushed.
+func (log *logger) errorEntry(ctx context.Context) Entry {
+	entry := log.fromContext(ctx)
+	if e, ok := entry.(*logger); ok && e.recorder == nil {
+		e.recorder = log.recorder
+	}
+	return entry
+}
+
 // fromContext returns a new `logger` using the same `Adapter` as the receiver,
 // encapsulating the specified `Context`.  The new `logger` has all registered
 // enrichment applied.
+//
+// Any level (see `ContextWithLevel()`) or recorder (see
+// `ContextWithRecorder()`) in the context is applied to the new `logger`.
+//
+// Fields added by enrichment functions are not added to any current group
+// of the receiver; the group is restored once enrichment is complete.
 func (log *logger) fromContext(ctx context.Context) Entry {
-	var logger = &logger{ctx, log.Adapter, log.copyFields()}
+	entry := log.clone(ctx, log.fields)
+	entry.level, entry.override = LevelFromContext(ctx)
+	entry.recorder = recorderFromContext(ctx)
+	if len(enrichmentFuncs) == 0 {
+		return entry
+	}
+	entry.group = nil
 
-	var enriched Entry = logger
+	var enriched Entry = entry
 	for _, enrich := range enrichmentFuncs {
 		enriched = enrich(ctx, enriched)
 	}
 
+	if enriched, ok := enriched.(*logger); ok {
+		enriched.group = log.group
+	}
 	return enriched
 }
 
@@ -85,6 +186,9 @@ func (log *logger) Trace(s string) {
 
 // Tracef emits a `Trace` level entry to the log using a format string and args.
 func (log *logger) Tracef(format string, args ...any) {
+	if log.discards(Trace) {
+		return
+	}
 	entry := log.entryFromArgs(args...)
 	entry.Trace(fmt.Sprintf(format, args...))
 }
@@ -96,6 +200,9 @@ func (log *logger) Debug(s string) {
 
 // Debugf emits a `Debug` level entry to the log using a format string and args.
 func (log *logger) Debugf(format string, args ...any) {
+	if log.discards(Debug) {
+		return
+	}
 	entry := log.entryFromArgs(args...)
 	entry.Debug(fmt.Sprintf(format, args...))
 }
@@ -107,6 +214,9 @@ func (log *logger) Info(s string) {
 
 // Infof emits an `Info` level entry to the log using a format string and args.
 func (log *logger) Infof(format string, args ...any) {
+	if log.discards(Info) {
+		return
+	}
 	entry := log.entryFromArgs(args...)
 	entry.Info(fmt.Sprintf(format, args...))
 }
@@ -118,6 +228,9 @@ func (log *logger) Warn(s string) {
 
 // Warnf emits a `Warn` level entry to the log using a format string and args.
 func (log *logger) Warnf(format string, args ...any) {
+	if log.discards(Warn) {
+		return
+	}
 	entry := log.entryFromArgs(args...)
 	entry.Warn(fmt.Sprintf(format, args...))
 }
@@ -134,8 +247,7 @@ func (log *logger) Warnf(format string, args ...any) {
 func (log *logger) Error(err any) {
 	switch err := err.(type) {
 	case error:
-		ctx := errorcontext.From(log.Context, err)
-		entry := log.fromContext(ctx)
+		entry := log.errorEntry(errorcontext.From(log.Context, err))
 		entry.Emit(Error, err.Error())
 	case string:
 		log.Emit(Error, err)
@@ -150,19 +262,34 @@ func (log *logger) Error(err any) {
 // enriched with any  information in the context supported by a registered
 // enrichment function.
 func (log *logger) Errorf(format string, args ...any) {
+	if log.discards(Error) {
+		return
+	}
 	entry := log.entryFromArgs(args...)
 	entry.Error(fmt.Errorf(format, args...))
 }
 
-// Fatal emits a string as a `Fatal` level entry to the log then terminates
-// the process with an exit code of 1.
+// fatalExit performs the exit behaviour of the logger following a `Fatal`
+// entry with the specified message.  If no exit behaviour has been set
+// the process is terminated by calling `ExitFn`.
+func (log *logger) fatalExit(s string) {
+	if log.onFatal == nil {
+		exit(1)
+		return
+	}
+	log.onFatal(1, s)
+}
+
+// Fatal emits a string as a `Fatal` level entry to the log then performs
+// the exit behaviour of the logger (by default, terminating the process with
+// an exit code of 1).
 func (log *logger) Fatal(s string) {
 	log.Emit(Fatal, s)
-	exit(1)
+	log.fatalExit(s)
 }
 
 // Fatalf emits a `Fatal` level entry to the log using a format string and args
-// then terminates the process with an exit code of 1..
+// then performs the exit behaviour of the logger.
 func (log *logger) Fatalf(format string, args ...any) {
 	entry := log.entryFromArgs(args...)
 	entry.Fatal(fmt.Sprintf(format, args...))
@@ -174,21 +301,102 @@ func (log *logger) Fatalf(format string, args ...any) {
 // enriched with any  information in the context supported by a registered
 // enrichment function.
 func (log *logger) FatalError(err error) {
-	ctx := errorcontext.From(log.Context, err)
-	entry := log.fromContext(ctx)
+	entry := log.errorEntry(errorcontext.From(log.Context, err))
 	entry.Fatal(err.Error())
 }
 
+// Panic emits a string as a `Panic` level entry to the log then panics with
+// the string.
+func (log *logger) Panic(s string) {
+	log.Emit(Panic, s)
+	panic(s)
+}
+
+// Panicf emits a `Panic` level entry to the log using a format string and args
+// then panics with the resulting string.
+func (log *logger) Panicf(format string, args ...any) {
+	entry := log.entryFromArgs(args...)
+	entry.Panic(fmt.Sprintf(format, args...))
+}
+
+// PanicError emits an error as a `Panic` level entry to the log then panics
+// with the error.
+//
+// If the error wraps a specific context then the error is logged using an entry
+// enriched with any  information in the error context supported by a registered
+// enrichment function.
+func (log *logger) PanicError(err error) {
+	entry := log.errorEntry(errorcontext.From(log.Context, err))
+	entry.Emit(Panic, err.Error())
+	panic(err)
+}
+
 // WithField returns a new `Entry` enriched with an additional
 // named field with the specified value.
 func (log *logger) WithField(name string, value any) Entry {
-	fields := log.copyFields()
-	if fields == nil {
-		fields = map[string]any{}
-	}
-	fields[name] = value
+	return log.withFields([]Field{Any(name, value)})
+}
+
+// WithFields returns a new `Entry` enriched with an additional field for
+// each named value in a map.  Fields are added in key order.
+func (log *logger) WithFields(fields map[string]any) Entry {
+	return log.withFields(mapToFields(fields))
+}
+
+// With returns a new `Entry` enriched with additional typed fields.  Any
+// existing field with the same name as an additional field is replaced.
+func (log *logger) With(fields ...Field) Entry {
+	return log.withFields(fields)
+}
+
+// WithValues returns a new `Entry` enriched with additional fields specified
+// as any combination of key/value pairs and typed `Field` values (see
+// `argsToFields()`).  Any existing field with the same name as an additional
+// field is replaced.
+//
+// Values are boxed in an `interface{}`; use `With()` to add typed fields
+// without boxing.
+func (log *logger) WithValues(args ...any) Entry {
+	return log.withFields(argsToFields(args))
+}
+
+// WithExitBehaviour returns a new `Logger` that performs a specified
+// `ExitBehaviour` following any `Fatal` entry, instead of the behaviour
+// of the receiver.
+func (log *logger) WithExitBehaviour(exit ExitBehaviour) Logger {
+	logger := log.clone(log.Context, log.fields)
+	logger.onFatal = exit
+	return logger
+}
 
-	return &logger{log.Context, log.Adapter, fields}
+// WithLevel returns a new `Logger` emitting only entries at a level enabled
+// by a specified `Leveler` (a `Level` or `*LevelVar`), replacing any `Leveler`
+// of the receiver.  A nil `Leveler` removes any such restriction.
+//
+// The level applies in addition to any `Levels` of the logger (see
+// `WithLevels()`) and any level applied by the `Adapter`.
+func (log *logger) WithLevel(level Leveler) Logger {
+	logger := log.clone(log.Context, log.fields)
+	logger.leveler = level
+	return logger
+}
+
+// WithRedactor returns a new `Logger` applying a specified `Redactor` to
+// the fields of any entry emitted, replacing any `Redactor` of the receiver.
+// A nil `Redactor` disables redaction.
+func (log *logger) WithRedactor(r *Redactor) Logger {
+	logger := log.clone(log.Context, log.fields)
+	logger.redactor = r
+	return logger
+}
+
+// WithScrubber returns a new `Logger` applying a specified `Scrubber` to
+// the message of any entry emitted, replacing any `Scrubber` of the receiver.
+// A nil `Scrubber` disables scrubbing.
+func (log *logger) WithScrubber(sc *Scrubber) Logger {
+	logger := log.clone(log.Context, log.fields)
+	logger.scrubber = sc
+	return logger
 }
 
 // WithContext returns a new `Entry`, enriched with any information
@@ -212,8 +420,16 @@ func (log *logger) NewEntry() Entry {
 	return log.fromContext(log.Context)
 }
 
+// loggerAdapter returns the Adapter to which the logger emits entries.
+func (log *logger) loggerAdapter() Adapter {
+	return log.Adapter
+}
+
 // UsingAdapter initialises a new Logger encapsulating a specified
 // context and using a supplied `Adapter`.
 func UsingAdapter(ctx context.Context, adapter Adapter) Logger {
-	return &logger{ctx, adapter, map[string]any{}}
+	log := &logger{Context: ctx, Adapter: adapter}
+	log.level, log.override = LevelFromContext(ctx)
+	log.recorder = recorderFromContext(ctx)
+	return log
 }
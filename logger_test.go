@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/blugnu/errorcontext"
 	"github.com/blugnu/go-logspy"
@@ -39,6 +40,48 @@ func (mock MockAdapter) WithField(name string, value any) Adapter {
 	return mock
 }
 
+// recordedEntry is an entry emitted via a recordingAdapter.
+type recordedEntry struct {
+	level  Level
+	s      string
+	fields map[string]any
+}
+
+// recordingAdapter is an Adapter that records all entries emitted
+// by it, and any adapters initialised from it.
+type recordingAdapter struct {
+	entries *[]recordedEntry
+	fields  map[string]any
+}
+
+// newRecordingAdapter returns a new recordingAdapter.
+func newRecordingAdapter() *recordingAdapter {
+	return &recordingAdapter{entries: &[]recordedEntry{}}
+}
+
+func (a *recordingAdapter) Emit(level Level, s string) {
+	*a.entries = append(*a.entries, recordedEntry{level, s, a.fields})
+}
+
+func (a *recordingAdapter) NewEntry() Adapter {
+	fields := map[string]any{}
+	for k, v := range a.fields {
+		fields[k] = v
+	}
+	return &recordingAdapter{a.entries, fields}
+}
+
+func (a *recordingAdapter) WithField(name string, value any) Adapter {
+	entry := a.NewEntry().(*recordingAdapter)
+	entry.fields[name] = value
+	return entry
+}
+
+// last returns the most recently recorded entry.
+func (a *recordingAdapter) last() recordedEntry {
+	return (*a.entries)[len(*a.entries)-1]
+}
+
 func TestLogEmissions(t *testing.T) {
 	// ARRANGE
 	var (
@@ -57,7 +100,7 @@ func TestLogEmissions(t *testing.T) {
 		exitFnWasCalled = false
 		exitCode = 0
 
-		emitLevel = Level(-1)
+		emitLevel = Level(-99)
 		emitString = ""
 		emitCalled = false
 		newEntryCalled = false
@@ -108,6 +151,9 @@ func TestLogEmissions(t *testing.T) {
 			{name: "fatal", fn: func(s string) { sut.Fatal(s) }, Level: Fatal, message: "test", callsExit: true},
 			{name: "fatalf", fn: func(s string) { sut.Fatalf("formatted: %s", errors.New(s)) }, Level: Fatal, message: "test", output: "formatted: test", callsExit: true},
 			{name: "fatalerror", fn: func(s string) { sut.FatalError(errors.New(s)) }, Level: Fatal, message: "test", callsExit: true},
+			{name: "panic", fn: func(s string) { defer func() { _ = recover() }(); sut.Panic(s) }, Level: Panic, message: "test", callsExit: false},
+			{name: "panicf", fn: func(s string) { defer func() { _ = recover() }(); sut.Panicf("formatted: %s", errors.New(s)) }, Level: Panic, message: "test", output: "formatted: test", callsExit: false},
+			{name: "panicerror", fn: func(s string) { defer func() { _ = recover() }(); sut.PanicError(errors.New(s)) }, Level: Panic, message: "test", callsExit: false},
 			{name: "withdecoration", fn: func(s string) {
 				od := enrichmentFuncs
 				defer func() { enrichmentFuncs = od }()
@@ -201,13 +247,13 @@ func TestLoggerWithContext(t *testing.T) {
 	adapter := MockAdapter{
 		newEntryCalled: &newEntryCalled,
 	}
-	sut := &logger{ctx, adapter, map[string]any{}}
+	sut := &logger{Context: ctx, Adapter: adapter}
 
 	// ACT
 	log := sut.WithContext(ctx)
 
 	// ASSERT
-	wanted := &logger{ctx, adapter, map[string]any{}}
+	wanted := &logger{Context: ctx, Adapter: adapter}
 	got := log
 	if !reflect.DeepEqual(wanted, got) {
 		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
@@ -222,13 +268,13 @@ func TestLoggerNewEntry(t *testing.T) {
 	adapter := MockAdapter{
 		newEntryCalled: &newEntryCalled,
 	}
-	sut := &logger{ctx, adapter, map[string]any{}}
+	sut := &logger{Context: ctx, Adapter: adapter}
 
 	// ACT
 	log := sut.NewEntry()
 
 	// ASSERT
-	wanted := &logger{ctx, adapter, map[string]any{}}
+	wanted := &logger{Context: ctx, Adapter: adapter}
 	got := log
 	if !reflect.DeepEqual(wanted, got) {
 		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
@@ -244,7 +290,7 @@ func TestUsingAdapter(t *testing.T) {
 	got := UsingAdapter(ctx, adapter).(*logger)
 
 	// ASSERT
-	wanted := &logger{ctx, adapter, map[string]any{}}
+	wanted := &logger{Context: ctx, Adapter: adapter}
 	if !reflect.DeepEqual(*wanted, *got) {
 		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
 	}
@@ -256,7 +302,7 @@ func TestLoggerEntryFromArgs(t *testing.T) {
 
 	bg := context.Background()
 	ctx := context.WithValue(bg, key(1), "value")
-	sut := &logger{Context: bg, Adapter: &nulAdapter{}, fields: map[string]any{}}
+	sut := &logger{Context: bg, Adapter: &nulAdapter{}}
 	rawerr := errors.New("error")
 	ctxerr := errorcontext.Wrap(ctx, rawerr)
 	ctxerr2 := errorcontext.Wrap(context.WithValue(bg, key(2), "key2"), rawerr)
@@ -269,10 +315,10 @@ func TestLoggerEntryFromArgs(t *testing.T) {
 		{name: "no args", args: []any{}, result: sut},
 		{name: "no errors", args: []any{"foo", 42}, result: sut},
 		{name: "error, no context", args: []any{"foo", rawerr}, result: sut},
-		{name: "error, with context", args: []any{"foo", ctxerr}, result: &logger{ctx, &nulAdapter{}, map[string]any{}}},
-		{name: "multiple errors, first with no context", args: []any{rawerr, ctxerr}, result: &logger{ctx, &nulAdapter{}, map[string]any{}}},
-		{name: "multiple errors, first with context", args: []any{ctxerr, rawerr}, result: &logger{ctx, &nulAdapter{}, map[string]any{}}},
-		{name: "multiple errors, both with context", args: []any{ctxerr, ctxerr2}, result: &logger{ctx, &nulAdapter{}, map[string]any{}}},
+		{name: "error, with context", args: []any{"foo", ctxerr}, result: &logger{Context: ctx, Adapter: &nulAdapter{}}},
+		{name: "multiple errors, first with no context", args: []any{rawerr, ctxerr}, result: &logger{Context: ctx, Adapter: &nulAdapter{}}},
+		{name: "multiple errors, first with context", args: []any{ctxerr, rawerr}, result: &logger{Context: ctx, Adapter: &nulAdapter{}}},
+		{name: "multiple errors, both with context", args: []any{ctxerr, ctxerr2}, result: &logger{Context: ctx, Adapter: &nulAdapter{}}},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -328,3 +374,259 @@ func TestLoggerfromContext(t *testing.T) {
 		}
 	})
 }
+
+func TestLoggerWithExitBehaviour(t *testing.T) {
+	// ARRANGE
+	ofn := ExitFn
+	defer func() { ExitFn = ofn }()
+	exitFnWasCalled := false
+	ExitFn = func(int) { exitFnWasCalled = true }
+
+	var (
+		code int
+		msg  string
+	)
+	behaviour := func(c int, m string) { code = c; msg = m }
+
+	ctx := context.Background()
+	root := UsingAdapter(ctx, &nulAdapter{})
+
+	// ACT
+	sut := root.WithExitBehaviour(behaviour)
+	sut.NewEntry().WithField("key", "value").Fatalf("formatted: %s", "test")
+
+	// ASSERT
+	t.Run("performs exit behaviour", func(t *testing.T) {
+		wanted := FatalPanic{Code: 1, Message: "formatted: test"}
+		got := FatalPanic{Code: code, Message: msg}
+		if wanted != got {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+
+	t.Run("does not call exit fn", func(t *testing.T) {
+		wanted := false
+		got := exitFnWasCalled
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("does not modify original logger", func(t *testing.T) {
+		// ACT
+		root.NewEntry().Fatal("test")
+
+		// ASSERT
+		wanted := true
+		got := exitFnWasCalled
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+}
+
+// fieldAdapter is a FieldAdapter emitting entries at or above a specified
+// level, recording the fields of the most recent entry.
+type fieldAdapter struct {
+	level  Level
+	fields []Field
+	calls  int
+}
+
+func (a *fieldAdapter) Emit(Level, string)            {}
+func (a *fieldAdapter) NewEntry() Adapter             { return a }
+func (a *fieldAdapter) WithField(string, any) Adapter { return a }
+func (a *fieldAdapter) Enabled(level Level) bool      { return level <= a.level }
+func (a *fieldAdapter) EmitFields(_ Level, _ string, fields []Field) {
+	a.calls++
+	a.fields = fields
+}
+
+func TestLoggerWith(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).NewEntry().WithField("a", "1")
+
+	// ACT
+	sut.With(String("b", "2"), Int64("c", 3), String("a", "4")).Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"a": "4", "b": "2", "c": int64(3)}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestLoggerWithKeyValuePairs(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+	// ACT
+	sut.WithValues("a", "1", Int64("b", 2), "c").Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"a": "1", "b": int64(2), "!BADKEY": "c"}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestLoggerWithFields(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).NewEntry().WithField("a", "1")
+
+	// ACT
+	sut.WithFields(map[string]any{"a": "2", "b": 3}).Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"a": "2", "b": 3}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestEnrichmentWithMultipleFields(t *testing.T) {
+	// ARRANGE
+	oef := enrichmentFuncs
+	defer func() { enrichmentFuncs = oef }()
+	RegisterEnrichment(func(ctx context.Context, e Enricher) Entry {
+		return e.WithValues("a", "1", "b", 2)
+	})
+	RegisterEnrichment(func(ctx context.Context, e Enricher) Entry {
+		return e.WithFields(map[string]any{"c": true})
+	})
+
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter)
+
+	// ACT
+	sut.NewEntry().Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"a": "1", "b": 2, "c": true}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestLoggerEmitsToFieldAdapter(t *testing.T) {
+	// ARRANGE
+	adapter := &fieldAdapter{level: Info}
+	entry := UsingAdapter(context.Background(), adapter).NewEntry().With(String("key", "value"))
+
+	testcases := []struct {
+		name   string
+		fn     func()
+		calls  int
+		fields []Field
+	}{
+		{name: "enabled", fn: func() { entry.Info("test") }, calls: 1, fields: []Field{String("key", "value")}},
+		{name: "disabled", fn: func() { entry.Debug("test") }, calls: 0},
+		{name: "disabled (formatted)", fn: func() { entry.Debugf("test %d", 1) }, calls: 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter.calls = 0
+			adapter.fields = nil
+
+			// ACT
+			tc.fn()
+
+			// ASSERT
+			t.Run("calls", func(t *testing.T) {
+				wanted := tc.calls
+				got := adapter.calls
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			t.Run("fields", func(t *testing.T) {
+				wanted := tc.fields
+				got := adapter.fields
+				if !reflect.DeepEqual(wanted, got) {
+					t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkLogger(b *testing.B) {
+	// ARRANGE
+	adapter := &fieldAdapter{level: Info}
+	entry := UsingAdapter(context.Background(), adapter).NewEntry().
+		With(String("request-id", "0123456789"), Int64("attempt", 3), Duration("elapsed", time.Second))
+
+	b.Run("disabled level", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			entry.Debug("message")
+		}
+	})
+
+	b.Run("enabled level", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			entry.Info("message")
+		}
+	})
+
+	b.Run("enabled level, with typed fields", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			entry.With(String("key", "value"), Bool("flag", true)).Info("message")
+		}
+	})
+
+	b.Run("enabled level, with field", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			entry.WithField("key", "value").WithField("flag", true).Info("message")
+		}
+	})
+}
+
+func TestLoggerPanic(t *testing.T) {
+	err := errors.New("error")
+
+	testcases := []struct {
+		name   string
+		fn     func(Entry)
+		result any
+	}{
+		{name: "panic", fn: func(e Entry) { e.Panic("message") }, result: "message"},
+		{name: "panicf", fn: func(e Entry) { e.Panicf("formatted: %d", 42) }, result: "formatted: 42"},
+		{name: "panicerror", fn: func(e Entry) { e.PanicError(err) }, result: err},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+			// ACT
+			var got any
+			func() {
+				defer func() { got = recover() }()
+				tc.fn(sut)
+			}()
+
+			// ASSERT
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+			if adapter.last().level != Panic {
+				t.Errorf("wanted %v, got %v", Panic, adapter.last().level)
+			}
+		})
+	}
+}
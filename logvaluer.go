@@ -0,0 +1,111 @@
+package unilog
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LogValuer is implemented by types that control their own representation
+// when used as the value of a field in a log entry.
+//
+// LogValue is called when an entry is emitted (not when the field is added),
+// so the cost of producing a value is incurred only for entries that are
+// actually emitted.  The value returned may be:
+//
+//   - any value, logged in place of the LogValuer (e.g. to hide secrets or
+//     provide a more concise representation);
+//   - another LogValuer, which is itself resolved;
+//   - a map[string]any, expanding the field into a Group of sub-fields, one
+//     for each key in the map (in key order); in flat formats these are named
+//     "<field>.<key>".
+//
+// Values are resolved to a maximum depth of 10 LogValue calls (including any
+// sub-fields); a LogValuer remaining at that depth is logged as-is.
+type LogValuer interface {
+	LogValue() any
+}
+
+// maxLogValueDepth is the maximum depth to which LogValuer values are resolved.
+const maxLogValueDepth = 10
+
+// resolveFields returns a slice of fields with any LogValuer values resolved,
+// including those in any Group.  If there are no LogValuer values the fields
+// are returned unmodified, otherwise a new slice is returned.
+func resolveFields(fields []Field) []Field {
+	if !hasValuer(fields) {
+		return fields
+	}
+
+	result := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		switch f.Kind {
+		case GroupKind:
+			result = append(result, Group(f.Key, resolveFields(f.AsGroup())...))
+		case AnyKind:
+			result = append(result, resolveField(f.Key, f.obj, 0))
+		default:
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// hasValuer returns true if any of a slice of fields (or the fields of any
+// Group) has a LogValuer value.
+func hasValuer(fields []Field) bool {
+	for _, f := range fields {
+		switch f.Kind {
+		case GroupKind:
+			if hasValuer(f.AsGroup()) {
+				return true
+			}
+		case AnyKind:
+			if _, ok := f.obj.(LogValuer); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveField returns a Field for a resolved named value.  If the value
+// resolves to a map[string]any, a Group is returned with a (resolved) field
+// for each value in the map, in key order.
+func resolveField(name string, value any, depth int) Field {
+	resolved := false
+	for depth < maxLogValueDepth {
+		lv, ok := value.(LogValuer)
+		if !ok {
+			break
+		}
+		value = logValue(lv)
+		resolved = true
+		depth++
+	}
+
+	if m, ok := value.(map[string]any); ok && resolved {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fields := make([]Field, len(keys))
+		for i, k := range keys {
+			fields[i] = resolveField(k, m[k], depth)
+		}
+		return Group(name, fields...)
+	}
+	return Any(name, value)
+}
+
+// logValue calls the LogValue function of a LogValuer, recovering from any
+// panic; if LogValue panics the value returned describes the panic.
+func logValue(lv LogValuer) (value any) {
+	defer func() {
+		if r := recover(); r != nil {
+			value = fmt.Sprintf("!PANIC: LogValue() of %T: %v", lv, r)
+		}
+	}()
+	return lv.LogValue()
+}
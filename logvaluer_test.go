@@ -0,0 +1,111 @@
+package unilog
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type testUser struct {
+	ID       int
+	Name     string
+	Password string
+}
+
+func (u testUser) LogValue() any {
+	return map[string]any{"id": u.ID, "name": u.Name}
+}
+
+type testMoney struct {
+	Units    int64
+	Currency string
+}
+
+func (m testMoney) LogValue() any {
+	return moneyString(m)
+}
+
+type moneyString testMoney
+
+func (m moneyString) LogValue() any {
+	return float64(m.Units) / 100.0
+}
+
+type testRecursive struct{}
+
+func (r testRecursive) LogValue() any { return r }
+
+type testPanics struct{}
+
+func (testPanics) LogValue() any { panic("oops") }
+
+func TestResolveFields(t *testing.T) {
+	testcases := []struct {
+		name   string
+		fields []Field
+		result []Field
+	}{
+		{name: "no fields", fields: nil, result: nil},
+		{name: "no valuers", fields: []Field{String("key", "value")}, result: []Field{String("key", "value")}},
+		{name: "valuer", fields: []Field{String("key", "value"), Any("amount", testMoney{Units: 1234})}, result: []Field{String("key", "value"), Any("amount", 12.34)}},
+		{name: "valuer expanded to sub-fields", fields: []Field{Any("user", testUser{ID: 1, Name: "jdoe", Password: "secret"})}, result: []Field{Group("user", Any("id", 1), Any("name", "jdoe"))}},
+		{name: "sub-field valuers", fields: []Field{Any("order", valuerFunc(func() any { return map[string]any{"user": testUser{ID: 1, Name: "jdoe"}} }))}, result: []Field{Group("order", Group("user", Any("id", 1), Any("name", "jdoe")))}},
+		{name: "map not expanded unless from valuer", fields: []Field{Any("map", map[string]any{"key": "value"}), Any("valuer", testMoney{})}, result: []Field{Any("map", map[string]any{"key": "value"}), Any("valuer", 0.0)}},
+		{name: "valuer in group", fields: []Field{Group("db", Any("amount", testMoney{Units: 1234}))}, result: []Field{Group("db", Any("amount", 12.34))}},
+		{name: "depth limit", fields: []Field{Any("recursive", testRecursive{})}, result: []Field{Any("recursive", testRecursive{})}},
+		{name: "panics", fields: []Field{Any("panics", testPanics{})}, result: []Field{Any("panics", "!PANIC: LogValue() of unilog.testPanics: oops")}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got := resolveFields(tc.fields)
+
+			// ASSERT
+			wanted := tc.result
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+// valuerFunc is a func implementing LogValuer.
+type valuerFunc func() any
+
+func (fn valuerFunc) LogValue() any { return fn() }
+
+func TestLoggerResolvesLogValuers(t *testing.T) {
+	// ARRANGE
+	calls := 0
+	valuer := valuerFunc(func() any { calls++; return "resolved" })
+
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).
+		WithRedactor(NewRedactor(RedactNames(Mask(), "user.name")))
+
+	// ACT
+	entry := sut.NewEntry().
+		WithField("lazy", valuer).
+		WithField("user", testUser{ID: 1, Name: "jdoe", Password: "secret"})
+
+	// ASSERT
+	t.Run("resolved lazily", func(t *testing.T) {
+		wanted := 0
+		got := calls
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("when emitted", func(t *testing.T) {
+		// ACT
+		entry.Info("test")
+
+		// ASSERT
+		wanted := map[string]any{"lazy": "resolved", "user.id": 1, "user.name": "********"}
+		got := adapter.last().fields
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package unilog
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Levels is a tree of level settings for named Loggers.  The effective level
+// of a named Logger is determined by the setting with the longest name that
+// is either the same as the name of the Logger or a dotted prefix of it; e.g.
+// the level for a Logger named "payments.db" is the level set for
+// "payments.db" if any, else the level set for "payments", else the root
+// level (set for an empty name).
+//
+// Levels are applied to a Logger (and all Loggers and entries initialised
+// from it) using `WithLevels()`.  A Levels may be modified at any time; any
+// change is effective immediately for all Loggers using it.
+//
+// The zero value is a Levels with a root level of Info (see `NewLevels()`).
+// A Levels is safe for concurrent use.
+type Levels struct {
+	mu       sync.Mutex         // serialises modifications
+	settings atomic.Value       // map[string]Level; replaced (not modified) by each change
+	reverts  map[string]*revert // initialised when first required
+}
+
+// afterFunc calls a func after a duration (see `time.AfterFunc()`); replaced
+// in tests.
+var afterFunc = time.AfterFunc
+
+// defaultSettings are the settings of a zero value Levels.
+var defaultSettings = map[string]Level{"": Info}
+
+// revert holds the setting to be restored for a name when a level set
+// using `SetFor()` expires.
+type revert struct {
+	level   Level
+	set     bool // false if no level was set for the name
+	expires time.Time
+	timer   *time.Timer
+	gen     int // identifies the most recent SetFor() for the name
+}
+
+// NewLevels returns a new Levels with a specified root level.
+func NewLevels(root Level) *Levels {
+	levels := &Levels{}
+	levels.settings.Store(map[string]Level{"": root})
+	return levels
+}
+
+// load returns the current settings.
+func (l *Levels) load() map[string]Level {
+	if settings, ok := l.settings.Load().(map[string]Level); ok {
+		return settings
+	}
+	return defaultSettings
+}
+
+// store replaces the current settings with a copy modified by a specified
+// func.  The caller must hold the mutex.
+func (l *Levels) store(fn func(map[string]Level)) {
+	current := l.load()
+	settings := make(map[string]Level, len(current)+1)
+	for k, v := range current {
+		settings[k] = v
+	}
+	fn(settings)
+	l.settings.Store(settings)
+}
+
+// cancelRevert cancels any pending revert for a name.  The caller must
+// hold the mutex.
+func (l *Levels) cancelRevert(name string) {
+	if r, ok := l.reverts[name]; ok {
+		r.timer.Stop()
+		delete(l.reverts, name)
+	}
+}
+
+// Set sets the level for a specified name.  An empty name sets the root level.
+//
+// Any pending revert of a level set for the name using `SetFor()` is
+// cancelled.
+func (l *Levels) Set(name string, level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cancelRevert(name)
+	l.store(func(settings map[string]Level) { settings[name] = level })
+}
+
+// SetFor sets the level for a specified name for a specified duration, after
+// which the setting for the name reverts to that in effect before the level
+// was set (removing the setting if there was none).  An empty name sets the
+// root level.
+//
+// If SetFor is called again for the same name before the duration has
+// expired, the new level replaces the previous one and the revert is
+// re-scheduled; the setting still reverts to that in effect before the first
+// call.
+func (l *Levels) SetFor(name string, level Level, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, pending := l.reverts[name]
+	if pending {
+		r.timer.Stop()
+	} else {
+		previous, set := l.load()[name]
+		r = &revert{level: previous, set: set}
+		if l.reverts == nil {
+			l.reverts = map[string]*revert{}
+		}
+		l.reverts[name] = r
+	}
+	r.gen++
+	gen := r.gen
+	r.expires = time.Now().Add(d)
+	r.timer = afterFunc(d, func() { l.revert(name, gen) })
+
+	l.store(func(settings map[string]Level) { settings[name] = level })
+}
+
+// revert restores the setting for a name set using `SetFor()`, unless the
+// revert has been cancelled or superseded.
+func (l *Levels) revert(name string, gen int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, ok := l.reverts[name]
+	if !ok || r.gen != gen {
+		return
+	}
+	delete(l.reverts, name)
+
+	l.store(func(settings map[string]Level) {
+		if r.set {
+			settings[name] = r.level
+		} else {
+			delete(settings, name)
+		}
+	})
+}
+
+// Expires returns the time at which a level set for a name using `SetFor()`
+// will revert.  If there is no pending revert for the name, false is
+// returned.
+func (l *Levels) Expires(name string) (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.reverts[name]; ok {
+		return r.expires, true
+	}
+	return time.Time{}, false
+}
+
+// Unset removes the level set for a specified name; the effective level for
+// the name is then determined by any setting for a prefix of the name (or the
+// root level).  The root level cannot be removed; specifying an empty name
+// has no effect.
+//
+// Any pending revert of a level set for the name using `SetFor()` is
+// cancelled.
+func (l *Levels) Unset(name string) {
+	if name == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cancelRevert(name)
+	l.store(func(settings map[string]Level) { delete(settings, name) })
+}
+
+// replace replaces all settings with a specified root level and levels for
+// names, cancelling any pending reverts.
+func (l *Levels) replace(root Level, names map[string]Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for name := range l.reverts {
+		l.cancelRevert(name)
+	}
+
+	settings := make(map[string]Level, len(names)+1)
+	for k, v := range names {
+		settings[k] = v
+	}
+	settings[""] = root
+	l.settings.Store(settings)
+}
+
+// Level returns the effective level for a specified name.
+func (l *Levels) Level(name string) Level {
+	settings := l.load()
+	for {
+		if level, ok := settings[name]; ok {
+			return level
+		}
+		i := strings.LastIndex(name, ".")
+		if i == -1 {
+			return settings[""]
+		}
+		name = name[:i]
+	}
+}
+
+// Settings returns a copy of the levels that have been set, keyed by name.
+// The root level has an empty name.
+func (l *Levels) Settings() map[string]Level {
+	current := l.load()
+	settings := make(map[string]Level, len(current))
+	for k, v := range current {
+		settings[k] = v
+	}
+	return settings
+}
+
+// Named returns a new `Logger` with a specified name.  If the receiver is
+// already named, the new name is appended to it, separated by '.'; i.e.
+// `log.Named("payments").Named("db")` returns a Logger named "payments.db".
+//
+// A named Logger adds a "logger" field, identifying the name, to all entries.
+// If the Logger has `Levels` (see `WithLevels()`), entries are emitted only
+// if enabled by the level for the name.
+func (log *logger) Named(name string) Logger {
+	if log.name != "" {
+		name = log.name + "." + name
+	}
+
+	entry := log.clone(log.Context, withFields(log.fields, String("logger", name)))
+	entry.name = name
+	return entry
+}
+
+// WithLevels returns a new `Logger` using a specified `Levels` to determine
+// the level of entries to be emitted, replacing any `Levels` of the receiver.
+// If `Levels` is nil, the level of entries emitted is not restricted (other
+// than by any level applied by the `Adapter`).
+func (log *logger) WithLevels(levels *Levels) Logger {
+	entry := log.clone(log.Context, log.fields)
+	entry.levels = levels
+	return entry
+}
@@ -0,0 +1,299 @@
+package unilog
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLevels(t *testing.T) {
+	// ARRANGE
+	sut := NewLevels(Info)
+	sut.Set("payments", Warn)
+	sut.Set("payments.db", Debug)
+
+	testcases := []struct {
+		name   string
+		result Level
+	}{
+		{name: "", result: Info},
+		{name: "orders", result: Info},
+		{name: "payments", result: Warn},
+		{name: "payments.api", result: Warn},
+		{name: "payments.db", result: Debug},
+		{name: "payments.db.pool", result: Debug},
+		{name: "payments-db", result: Info},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got := sut.Level(tc.name)
+
+			// ASSERT
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestLevelsUnset(t *testing.T) {
+	// ARRANGE
+	sut := NewLevels(Info)
+	sut.Set("payments", Warn)
+	sut.Set("payments.db", Debug)
+
+	// ACT
+	sut.Unset("payments.db")
+	sut.Unset("")
+
+	// ASSERT
+	wanted := map[string]Level{"": Info, "payments": Warn}
+	got := sut.Settings()
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+	}
+}
+
+func TestLevelsSettingsReturnsCopy(t *testing.T) {
+	// ARRANGE
+	sut := NewLevels(Info)
+
+	// ACT
+	sut.Settings()["payments"] = Debug
+
+	// ASSERT
+	wanted := Info
+	got := sut.Level("payments")
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
+
+func TestLevelsZeroValue(t *testing.T) {
+	// ARRANGE
+	sut := &Levels{}
+
+	t.Run("root level", func(t *testing.T) {
+		wanted := map[string]Level{"": Info}
+		got := sut.Settings()
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+		}
+	})
+
+	t.Run("set and unset", func(t *testing.T) {
+		// ACT
+		sut.Set("payments", Debug)
+		sut.SetFor("payments.db", Trace, time.Hour)
+		sut.Unset("payments.db")
+
+		// ASSERT
+		wanted := map[string]Level{"": Info, "payments": Debug}
+		got := sut.Settings()
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+		}
+	})
+}
+
+func TestLevelsConcurrentUse(t *testing.T) {
+	// ARRANGE
+	sut := NewLevels(Info)
+	wg := sync.WaitGroup{}
+
+	// ACT
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); sut.Set("payments", Debug) }()
+		go func() { defer wg.Done(); _ = sut.Level("payments.db") }()
+	}
+	wg.Wait()
+
+	// ASSERT
+	wanted := Debug
+	got := sut.Level("payments.db")
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
+
+func TestNamed(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	root := UsingAdapter(context.Background(), adapter)
+
+	// ACT
+	sut := root.Named("payments").Named("db")
+	sut.NewEntry().WithField("id", 1).Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"logger": "payments.db", "id": 1}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
+
+func TestNamedWithLevels(t *testing.T) {
+	// ARRANGE
+	levels := NewLevels(Info)
+	adapter := newRecordingAdapter()
+	root := UsingAdapter(context.Background(), adapter).WithLevels(levels)
+	payments := root.Named("payments").NewEntry()
+	db := root.Named("payments").Named("db").NewEntry()
+	orders := root.Named("orders").NewEntry()
+
+	testcases := []struct {
+		name    string
+		arrange func()
+		entry   Entry
+		level   Level
+		emitted bool
+	}{
+		{name: "root level, enabled", entry: orders, level: Info, emitted: true},
+		{name: "root level, disabled", entry: orders, level: Debug, emitted: false},
+		{name: "name level, enabled", arrange: func() { levels.Set("payments.db", Debug) }, entry: db, level: Debug, emitted: true},
+		{name: "name level, does not apply to parent", entry: payments, level: Debug, emitted: false},
+		{name: "parent level", arrange: func() { levels.Set("payments", Error) }, entry: db, level: Debug, emitted: true},
+		{name: "parent level, disabled", entry: payments, level: Warn, emitted: false},
+		{name: "name level removed", arrange: func() { levels.Unset("payments.db") }, entry: db, level: Warn, emitted: false},
+		{name: "root level changed", arrange: func() { levels.Set("", Trace) }, entry: orders, level: Trace, emitted: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			if tc.arrange != nil {
+				tc.arrange()
+			}
+			*adapter.entries = nil
+
+			// ACT
+			tc.entry.Emit(tc.level, "test")
+
+			// ASSERT
+			wanted := tc.emitted
+			got := len(*adapter.entries) == 1
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+// timers records the funcs scheduled using afterFunc, to be called (as if
+// their durations had elapsed) by expire().
+type timers struct {
+	mu    sync.Mutex
+	funcs []func()
+}
+
+func (ts *timers) afterFunc(d time.Duration, fn func()) *time.Timer {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.funcs = append(ts.funcs, fn)
+	return time.NewTimer(d)
+}
+
+func (ts *timers) expire() {
+	ts.mu.Lock()
+	funcs := ts.funcs
+	ts.funcs = nil
+	ts.mu.Unlock()
+
+	for _, fn := range funcs {
+		fn()
+	}
+}
+
+func TestLevelsSetFor(t *testing.T) {
+	testcases := []struct {
+		name   string
+		exec   func(*Levels)
+		result map[string]Level
+	}{
+		{name: "reverts to previous setting",
+			exec: func(sut *Levels) {
+				sut.SetFor("payments", Debug, time.Minute)
+			},
+			result: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "reverts to no setting",
+			exec: func(sut *Levels) {
+				sut.SetFor("orders", Debug, time.Minute)
+			},
+			result: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "repeated reverts to original setting",
+			exec: func(sut *Levels) {
+				sut.SetFor("payments", Debug, time.Minute)
+				sut.SetFor("payments", Trace, time.Minute)
+			},
+			result: map[string]Level{"": Info, "payments": Warn},
+		},
+		{name: "set cancels revert",
+			exec: func(sut *Levels) {
+				sut.SetFor("payments", Debug, time.Minute)
+				sut.Set("payments", Error)
+			},
+			result: map[string]Level{"": Info, "payments": Error},
+		},
+		{name: "unset cancels revert",
+			exec: func(sut *Levels) {
+				sut.SetFor("payments", Debug, time.Minute)
+				sut.Unset("payments")
+			},
+			result: map[string]Level{"": Info},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			ts := &timers{}
+			oaf := afterFunc
+			defer func() { afterFunc = oaf }()
+			afterFunc = ts.afterFunc
+
+			sut := NewLevels(Info)
+			sut.Set("payments", Warn)
+
+			// ACT
+			tc.exec(sut)
+			ts.expire()
+
+			// ASSERT
+			wanted := tc.result
+			got := sut.Settings()
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+			}
+
+			if _, got := sut.Expires("payments"); got {
+				t.Errorf("wanted no pending revert")
+			}
+		})
+	}
+}
+
+func TestLevelsSetForExpires(t *testing.T) {
+	// ARRANGE
+	sut := NewLevels(Info)
+	before := time.Now()
+
+	// ACT
+	sut.SetFor("payments", Debug, time.Minute)
+	defer sut.Set("payments", Debug) // cancels the revert
+
+	// ASSERT
+	if got := sut.Level("payments"); got != Debug {
+		t.Errorf("wanted %v, got %v", Debug, got)
+	}
+
+	expires, ok := sut.Expires("payments")
+	if !ok || expires.Before(before.Add(time.Minute)) {
+		t.Errorf("wanted expiry after %v, got %v (%v)", before.Add(time.Minute), expires, ok)
+	}
+}
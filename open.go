@@ -0,0 +1,192 @@
+package unilog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// AdapterFactory is a func returning an Adapter configured by a sink
+// specification (see `Open()`).  The Scheme of the specification includes
+// any transport, e.g. "json+file"; the Scheme identifying the factory is the
+// part preceding any '+'.
+type AdapterFactory func(spec *url.URL) (Adapter, error)
+
+var (
+	adapterFactoriesMu sync.RWMutex
+	adapterFactories   = map[string]AdapterFactory{}
+)
+
+func init() {
+	for scheme, enc := range map[string]encoder{
+		"json":   encodeJSON,
+		"logfmt": encodeLogfmt,
+		"text":   encodeText,
+	} {
+		_ = RegisterAdapterFactory(scheme, writerAdapterFactory(enc))
+	}
+	_ = RegisterAdapterFactory("stdlog", func(*url.URL) (Adapter, error) {
+		return &stdlogAdapter{fields: map[string]any{}}, nil
+	})
+	_ = RegisterAdapterFactory("nul", func(*url.URL) (Adapter, error) {
+		return &nulAdapter{}, nil
+	})
+}
+
+// RegisterAdapterFactory registers an AdapterFactory for a specified scheme
+// (case-insensitive), for use by `Open()`.  Adapters (such as unilog4logrus)
+// typically register a factory in an `init()` func:
+//
+//	func init() {
+//		unilog.RegisterAdapterFactory("logrus", func(spec *url.URL) (unilog.Adapter, error) {
+//			...
+//		})
+//	}
+//
+// An error is returned if the scheme is not valid (a scheme may not contain
+// '+') or a factory is already registered for the scheme.
+func RegisterAdapterFactory(scheme string, factory AdapterFactory) error {
+	scheme = strings.ToLower(scheme)
+	if _, err := url.Parse(scheme + ":"); scheme == "" || err != nil || strings.Contains(scheme, "+") {
+		return fmt.Errorf("unilog: invalid adapter scheme: %q", scheme)
+	}
+	if factory == nil {
+		return fmt.Errorf("unilog: nil adapter factory: %q", scheme)
+	}
+
+	adapterFactoriesMu.Lock()
+	defer adapterFactoriesMu.Unlock()
+
+	if _, ok := adapterFactories[scheme]; ok {
+		return fmt.Errorf("unilog: adapter scheme already registered: %q", scheme)
+	}
+	adapterFactories[scheme] = factory
+	return nil
+}
+
+// Open returns a Logger emitting entries to each of a number of sinks, each
+// identified by a URI-style specification of the form:
+//
+//	<scheme>[+<transport>]://[<path>][?level=<level>]
+//
+// where <scheme> identifies a registered `AdapterFactory`.  The built-in
+// "json", "logfmt" and "text" schemes support "stdout", "stderr" (the
+// default) and "file" transports; the "stdlog" and "nul" schemes take no
+// transport.  e.g:
+//
+//	log, err := unilog.Open(
+//		"json+file:///var/log/app.log?level=debug",
+//		"logfmt+stderr://?level=warn",
+//	)
+//
+// If a level is specified, the sink emits only entries at that level or
+// more severe; the level is applied in addition to any `Levels` of the
+// Logger.  Open with no specifications returns `Nul()`.
+//
+// An error is returned if any specification is not valid, identifies an
+// unregistered scheme, or its factory returns an error.
+func Open(uri ...string) (Logger, error) {
+	if len(uri) == 0 {
+		return Nul(), nil
+	}
+
+	adapter, err := openAdapter(uri...)
+	if err != nil {
+		return nil, err
+	}
+	return UsingAdapter(context.Background(), adapter), nil
+}
+
+// openAdapter returns an Adapter emitting entries to each of the sinks
+// identified by specified specifications (see `Open()`).  If any sink cannot
+// be opened, any sinks already opened are closed.
+func openAdapter(uri ...string) (Adapter, error) {
+	sinks := make([]sink, 0, len(uri))
+	for _, s := range uri {
+		sink, err := openSink(s)
+		if err != nil {
+			for _, opened := range sinks {
+				closeAdapter(opened.adapter)
+			}
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if len(sinks) == 1 && !sinks[0].hasLevel {
+		return sinks[0].adapter, nil
+	}
+	return &fanoutAdapter{sinks: sinks}, nil
+}
+
+// openSink returns the sink identified by a specification.
+func openSink(uri string) (sink, error) {
+	spec, err := url.Parse(uri)
+	if err != nil {
+		return sink{}, fmt.Errorf("unilog: open: %w", err)
+	}
+
+	scheme := strings.ToLower(spec.Scheme)
+	if i := strings.IndexByte(scheme, '+'); i != -1 {
+		scheme = scheme[:i]
+	}
+
+	adapterFactoriesMu.RLock()
+	factory, ok := adapterFactories[scheme]
+	adapterFactoriesMu.RUnlock()
+	if !ok {
+		return sink{}, fmt.Errorf("unilog: open: %s: unknown adapter scheme: %q", uri, scheme)
+	}
+
+	result := sink{}
+	if s := spec.Query().Get("level"); s != "" {
+		if result.level, err = ParseLevel(s); err != nil {
+			return sink{}, fmt.Errorf("unilog: open: %s: %w", uri, err)
+		}
+		result.hasLevel = true
+	}
+
+	if result.adapter, err = factory(spec); err != nil {
+		return sink{}, fmt.Errorf("unilog: open: %s: %w", uri, err)
+	}
+	return result, nil
+}
+
+// writerAdapterFactory returns an AdapterFactory returning a writerAdapter
+// with a specified encoder, writing to stdout, stderr or a file according to
+// the transport of a specification.
+func writerAdapterFactory(enc encoder) AdapterFactory {
+	return func(spec *url.URL) (Adapter, error) {
+		var transport string
+		if i := strings.IndexByte(spec.Scheme, '+'); i != -1 {
+			transport = strings.ToLower(spec.Scheme[i+1:])
+		}
+
+		var w io.Writer
+		switch transport {
+		case "", "stderr":
+			w = os.Stderr
+		case "stdout":
+			w = os.Stdout
+		case "file":
+			// file:///abs/path has an empty Host; file://rel/path does not
+			path := spec.Host + spec.Path
+			if path == "" {
+				return nil, fmt.Errorf("file path is required")
+			}
+			f, err := openFile(path)
+			if err != nil {
+				return nil, err
+			}
+			w = f
+		default:
+			return nil, fmt.Errorf("invalid transport: %q", transport)
+		}
+
+		return newWriterAdapter(w, enc), nil
+	}
+}
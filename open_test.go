@@ -0,0 +1,161 @@
+package unilog
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	debug := filepath.Join(dir, "debug.log")
+	warn := filepath.Join(dir, "warn.log")
+
+	// ACT
+	log, err := Open(
+		"logfmt+file://"+debug+"?level=debug",
+		"JSON+file://"+warn+"?level=warn",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer closeAdapter(log.(*logger).Adapter)
+	entry := log.NewEntry()
+	entry.Trace("trace")
+	entry.Debug("debug")
+	entry.Warn("warn")
+
+	// ASSERT
+	testcases := []struct {
+		path   string
+		result []string
+	}{
+		{path: debug, result: []string{"level=debug msg=debug", "level=warn msg=warn"}},
+		{path: warn, result: []string{`"level":"warn","msg":"warn"`}},
+	}
+	for _, tc := range testcases {
+		t.Run(filepath.Base(tc.path), func(t *testing.T) {
+			data, _ := os.ReadFile(tc.path)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if len(lines) != len(tc.result) {
+				t.Fatalf("wanted %d entries, got:\n%s", len(tc.result), data)
+			}
+			for i, s := range tc.result {
+				if !strings.Contains(lines[i], s) {
+					t.Errorf("\nwanted %s\ngot    %s", s, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAdapters(t *testing.T) {
+	testcases := []struct {
+		uri    []string
+		result Adapter
+	}{
+		{uri: nil, result: &nulAdapter{}},
+		{uri: []string{"text://"}, result: newWriterAdapter(os.Stderr, encodeText)},
+		{uri: []string{"logfmt+stdout://"}, result: newWriterAdapter(os.Stdout, encodeLogfmt)},
+		{uri: []string{"stdlog://"}, result: &stdlogAdapter{fields: map[string]any{}}},
+		{uri: []string{"nul:"}, result: &nulAdapter{}},
+		{uri: []string{"nul://?level=error"}, result: &fanoutAdapter{}},
+		{uri: []string{"nul://", "stdlog://"}, result: &fanoutAdapter{}},
+	}
+	for _, tc := range testcases {
+		t.Run(strings.Join(tc.uri, ","), func(t *testing.T) {
+			// ACT
+			log, err := Open(tc.uri...)
+
+			// ASSERT
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			wanted := reflect.TypeOf(tc.result)
+			got := reflect.TypeOf(log.(*logger).Adapter)
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	testcases := []string{
+		"::invalid",
+		"xml://",
+		"json+file://",
+		"json+syslog://",
+		"json+stderr://?level=verbose",
+		"json+file://" + filepath.Join(t.TempDir(), "missing", "app.log"),
+	}
+	for _, uri := range testcases {
+		t.Run(uri, func(t *testing.T) {
+			// ACT
+			log, err := Open("nul://", uri)
+
+			// ASSERT
+			if err == nil || log != nil {
+				t.Errorf("wanted error, got %v (%v)", err, log)
+			}
+		})
+	}
+}
+
+func TestRegisterAdapterFactory(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	factory := func(spec *url.URL) (Adapter, error) {
+		return adapter.WithField("host", spec.Host), nil
+	}
+	defer func() {
+		adapterFactoriesMu.Lock()
+		delete(adapterFactories, "recording")
+		adapterFactoriesMu.Unlock()
+	}()
+
+	// ACT
+	err := RegisterAdapterFactory("Recording", factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	log, err := Open("recording+tcp://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	log.NewEntry().Info("message")
+
+	// ASSERT
+	t.Run("emits to registered adapter", func(t *testing.T) {
+		wanted := recordedEntry{Info, "message", map[string]any{"host": "example.com"}}
+		got := adapter.last()
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testcases := []struct {
+			scheme  string
+			factory AdapterFactory
+		}{
+			{scheme: "", factory: factory},
+			{scheme: "a+b", factory: factory},
+			{scheme: "json", factory: factory},
+			{scheme: "RECORDING", factory: factory},
+			{scheme: "other", factory: nil},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.scheme, func(t *testing.T) {
+				err := RegisterAdapterFactory(tc.scheme, tc.factory)
+				if err == nil {
+					t.Error("wanted error, got nil")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package unilog
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultRecorderSize is the number of entries retained by a recorder
+// if no (or an invalid) size is specified.
+const defaultRecorderSize = 100
+
+// recorder is a bounded (ring) buffer of entries that were not emitted
+// (because their level was not enabled) by loggers with a context to which
+// the recorder is bound (see `ContextWithRecorder()`).
+type recorder struct {
+	mu      sync.Mutex
+	entries []bufferedEntry
+	next    int  // the index at which the next entry is recorded
+	full    bool // true if the buffer has wrapped
+	closed  bool
+}
+
+// bufferedEntry is an entry held by a recorder.
+type bufferedEntry struct {
+	log   *logger
+	level Level
+	s     string
+	time  time.Time
+}
+
+// ContextWithRecorder returns a new context, derived from a parent context,
+// with a "flight recorder" retaining the most recent entries (up to a
+// specified number) that are not emitted because their level is not enabled.
+// If the specified size is not greater than zero a default of 100 is used.
+//
+// When an `Error` (or more severe) entry is emitted by an `Entry` initialised
+// from the context (or a context derived from it), any recorded entries are
+// emitted first, providing the lead-up to the error.  Entries emitted from the
+// recorder have additional fields:
+//
+//	backfilled  true
+//	recorded    the time at which the entry was recorded
+//
+// A func is also returned which must be called when the context is no longer
+// required (e.g. when a request is complete), discarding any recorded entries.
+// No further entries are recorded once the func has been called.
+//
+// NOTE: entries are recorded with their fields (unresolved) and are subject
+// to any redaction only if and when they are emitted.
+func ContextWithRecorder(ctx context.Context, size int) (context.Context, func()) {
+	if size <= 0 {
+		size = defaultRecorderSize
+	}
+	rec := &recorder{entries: make([]bufferedEntry, size)}
+	return context.WithValue(ctx, recorderContextKey, rec), rec.close
+}
+
+// recorderFromContext returns any recorder in a specified context, or nil.
+func recorderFromContext(ctx context.Context) *recorder {
+	if ctx == nil {
+		return nil
+	}
+	rec, _ := ctx.Value(recorderContextKey).(*recorder)
+	return rec
+}
+
+// record adds an entry to the recorder, replacing the oldest entry if the
+// recorder is full.
+func (rec *recorder) record(log *logger, level Level, s string) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.closed {
+		return
+	}
+	rec.entries[rec.next] = bufferedEntry{log: log, level: level, s: s, time: time.Now()}
+	rec.next = (rec.next + 1) % len(rec.entries)
+	rec.full = rec.full || rec.next == 0
+}
+
+// flush emits any recorded entries, oldest first, and resets the recorder.
+func (rec *recorder) flush() {
+	rec.mu.Lock()
+	entries := rec.take()
+	rec.mu.Unlock()
+
+	for _, e := range entries {
+		entry := e.log.clone(e.log.Context, withFields(e.log.fields, Bool("backfilled", true), Time("recorded", e.time)))
+		entry.emit(e.level, e.s)
+	}
+}
+
+// take returns the recorded entries, oldest first, and resets the recorder.
+// The caller must hold the mutex.
+func (rec *recorder) take() []bufferedEntry {
+	var entries []bufferedEntry
+	if rec.full {
+		entries = append(entries, rec.entries[rec.next:]...)
+	}
+	entries = append(entries, rec.entries[:rec.next]...)
+
+	for i := range rec.entries {
+		rec.entries[i] = bufferedEntry{}
+	}
+	rec.next = 0
+	rec.full = false
+	return entries
+}
+
+// close discards any recorded entries; no further entries are recorded.
+func (rec *recorder) close() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.take()
+	rec.closed = true
+}
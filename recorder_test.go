@@ -0,0 +1,196 @@
+package unilog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/blugnu/errorcontext"
+)
+
+func TestRecorder(t *testing.T) {
+	testcases := []struct {
+		name   string
+		size   int
+		exec   func(Entry)
+		result []string
+	}{
+		{name: "no error",
+			size: 10,
+			exec: func(log Entry) {
+				log.Debug("debug")
+				log.Info("info")
+			},
+			result: []string{"INFO: info"},
+		},
+		{name: "error",
+			size: 10,
+			exec: func(log Entry) {
+				log.Debug("debug")
+				log.Tracef("trace %d", 1)
+				log.Info("info")
+				log.Error("error")
+			},
+			result: []string{"INFO: info", "DEBUG: debug (backfilled)", "TRACE: trace 1 (backfilled)", "ERROR: error"},
+		},
+		{name: "error/recorded entries are flushed only once",
+			size: 10,
+			exec: func(log Entry) {
+				log.Debug("debug")
+				log.Error("error 1")
+				log.Error("error 2")
+			},
+			result: []string{"DEBUG: debug (backfilled)", "ERROR: error 1", "ERROR: error 2"},
+		},
+		{name: "error/recorder wrapped",
+			size: 2,
+			exec: func(log Entry) {
+				log.Debug("debug 1")
+				log.Debug("debug 2")
+				log.Debug("debug 3")
+				log.Error(fmt.Errorf("error"))
+			},
+			result: []string{"DEBUG: debug 2 (backfilled)", "DEBUG: debug 3 (backfilled)", "ERROR: error"},
+		},
+		{name: "error/entry with fields",
+			size: 10,
+			exec: func(log Entry) {
+				log.WithValues("id", 42).Debug("debug")
+				log.Errorf("error %d", 1)
+			},
+			result: []string{"DEBUG: debug (backfilled) id=42", "ERROR: error 1"},
+		},
+		{name: "error/with context of a different context",
+			size: 10,
+			exec: func(log Entry) {
+				log.Debug("debug")
+				log.Error(errorcontext.Wrap(context.Background(), errors.New("error")))
+			},
+			result: []string{"DEBUG: debug (backfilled)", "ERROR: error"},
+		},
+		{name: "errorf/with context of a different context",
+			size: 10,
+			exec: func(log Entry) {
+				log.Debug("debug")
+				log.Errorf("failed: %v", errorcontext.Wrap(context.Background(), errors.New("error")))
+			},
+			result: []string{"DEBUG: debug (backfilled)", "ERROR: failed: error"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			log := UsingAdapter(context.Background(), adapter).WithLevels(NewLevels(Info))
+
+			ctx, end := ContextWithRecorder(context.Background(), tc.size)
+			defer end()
+
+			// ACT
+			tc.exec(log.WithContext(ctx))
+
+			// ASSERT
+			wanted := tc.result
+			got := []string{}
+			for _, e := range *adapter.entries {
+				s := logPrefix[e.level] + ": " + e.s
+				if e.fields["backfilled"] == true {
+					s += " (backfilled)"
+					if _, ok := e.fields["recorded"]; !ok {
+						t.Errorf("backfilled entry has no recorded time")
+					}
+				}
+				if id, ok := e.fields["id"]; ok {
+					s += fmt.Sprintf(" id=%v", id)
+				}
+				got = append(got, s)
+			}
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+			}
+		})
+	}
+}
+
+func TestRecorderFatalAndPanicError(t *testing.T) {
+	testcases := []struct {
+		name   string
+		exec   func(Entry, error)
+		result []recordedEntry
+	}{
+		{name: "fatal error",
+			exec: func(log Entry, err error) { log.FatalError(err) },
+			result: []recordedEntry{
+				{level: Debug, s: "debug", fields: map[string]any{"backfilled": true}},
+				{level: Fatal, s: "error"},
+				{level: Error, s: "unrelated"},
+			},
+		},
+		{name: "panic error",
+			exec: func(log Entry, err error) {
+				defer func() { _ = recover() }()
+				log.PanicError(err)
+			},
+			result: []recordedEntry{
+				{level: Debug, s: "debug", fields: map[string]any{"backfilled": true}},
+				{level: Panic, s: "error"},
+				{level: Error, s: "unrelated"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			log := UsingAdapter(context.Background(), adapter).
+				WithLevels(NewLevels(Info)).
+				WithExitBehaviour(func(int, string) {})
+
+			ctx, end := ContextWithRecorder(context.Background(), 10)
+			defer end()
+			entry := log.WithContext(ctx)
+			entry.Debug("debug")
+
+			// ACT
+			tc.exec(entry, errorcontext.Wrap(context.Background(), errors.New("error")))
+			entry.Error("unrelated")
+
+			// ASSERT
+			wanted := tc.result
+			got := *adapter.entries
+			for i := range got {
+				delete(got[i].fields, "recorded")
+				if len(got[i].fields) == 0 {
+					got[i].fields = nil
+				}
+			}
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestRecorderEnd(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	log := UsingAdapter(context.Background(), adapter).WithLevels(NewLevels(Info))
+
+	ctx, end := ContextWithRecorder(context.Background(), 0)
+	entry := log.WithContext(ctx)
+	entry.Debug("discarded")
+
+	// ACT
+	end()
+	entry.Debug("not recorded")
+	entry.Error("error")
+
+	// ASSERT
+	wanted := []recordedEntry{{level: Error, s: "error"}}
+	got := *adapter.entries
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
@@ -0,0 +1,110 @@
+package unilog
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// RecoverOption is an option for configuring the recovery of a panic using
+// `Recover()` or `Go()`.
+type RecoverOption func(*recoverOptions)
+
+// recoverOptions holds the options for recovering a panic.
+type recoverOptions struct {
+	level   Level
+	repanic bool
+}
+
+// RecoverLevel specifies the level of the entry emitted when a panic is
+// recovered.  The default level is `Error`; if `Fatal` is specified, the
+// exit behaviour of the logger is performed after the entry is emitted.
+func RecoverLevel(level Level) RecoverOption {
+	return func(opts *recoverOptions) { opts.level = level }
+}
+
+// Repanic specifies that a recovered panic is to be re-panicked (with the
+// recovered value) after the entry is emitted.
+func Repanic() RecoverOption {
+	return func(opts *recoverOptions) { opts.repanic = true }
+}
+
+// Recover recovers from any panic, emitting an entry with a "panic" field
+// (the recovered value) and a "stack" field (the stack trace of the
+// goroutine).  Recover has no effect unless deferred:
+//
+//	defer log.Recover()
+//
+// The entry is emitted at `Error` level (or as specified by `RecoverLevel()`).
+// If the recovered value is an error, the entry is enriched with any context
+// in the error (see `errorcontext`).
+func (log *logger) Recover(opts ...RecoverOption) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	options := recoverOptions{level: Error}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	entry := log.withFields([]Field{Any("panic", r), String("stack", string(debug.Stack()))}).entryFromArgs(r)
+	s := fmt.Sprintf("recovered from panic: %v", r)
+	if options.level == Fatal {
+		entry.Fatal(s)
+	} else {
+		entry.Emit(options.level, s)
+	}
+
+	if options.repanic {
+		panic(r)
+	}
+}
+
+// Go calls a specified func in a new goroutine, with the context passed to
+// the func.  Any panic in the goroutine is recovered (see `Recover()`) using
+// an entry initialised from the context with the `Logger` in the context or
+// the default `Logger` (see `LoggerFromContext()`).
+//
+// If the `Logger` discards all entries when the goroutine starts (e.g.
+// `Nul()`, any Logger initialised from it, or a `SwappableLogger` currently
+// using its Adapter) the panic is not recovered, since it could not be logged.
+func Go(ctx context.Context, fn func(context.Context), opts ...RecoverOption) {
+	go func() {
+		if log := LoggerFromContext(ctx); !isNul(log) {
+			defer log.WithContext(ctx).Recover(opts...)
+		}
+		fn(ctx)
+	}()
+}
+
+// isNul returns true if a Logger discards all entries, i.e. it emits entries
+// only to the Adapter of `Nul()`, directly or through any SwappableLogger,
+// configured BootstrapLogger or sinks (see `Open()`).
+func isNul(log Logger) bool {
+	al, ok := log.(adapterLogger)
+	return ok && isNulAdapter(al.loggerAdapter())
+}
+
+// isNulAdapter returns true if an Adapter emits entries only to the Adapter
+// of `Nul()`.
+func isNulAdapter(adapter Adapter) bool {
+	switch a := adapter.(type) {
+	case *nulAdapter:
+		return true
+	case *swappableAdapter:
+		return isNulAdapter(a.current())
+	case *bootstrapAdapter:
+		return isNulAdapter(a.configured())
+	case *fanoutAdapter:
+		for _, sink := range a.sinks {
+			if !isNulAdapter(sink.adapter) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,188 @@
+package unilog
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	testcases := []struct {
+		name    string
+		opts    []RecoverOption
+		value   any
+		level   Level
+		message string
+	}{
+		{name: "string", value: "boom", level: Error, message: "recovered from panic: boom"},
+		{name: "error", value: errors.New("failed"), level: Error, message: "recovered from panic: failed"},
+		{name: "warn level", opts: []RecoverOption{RecoverLevel(Warn)}, value: 42, level: Warn, message: "recovered from panic: 42"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+			// ACT
+			func() {
+				defer sut.Recover(tc.opts...)
+				panic(tc.value)
+			}()
+
+			// ASSERT
+			entry := adapter.last()
+			if entry.level != tc.level || entry.s != tc.message {
+				t.Errorf("\nwanted %v: %s\ngot    %v: %s", tc.level, tc.message, entry.level, entry.s)
+			}
+			if entry.fields["panic"] != tc.value {
+				t.Errorf("wanted panic %v, got %v", tc.value, entry.fields["panic"])
+			}
+			if stack, _ := entry.fields["stack"].(string); !strings.Contains(stack, "TestRecover") {
+				t.Errorf("wanted stack, got %q", stack)
+			}
+		})
+	}
+}
+
+func TestRecoverWithNoPanic(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+	// ACT
+	func() {
+		defer sut.Recover()
+	}()
+
+	// ASSERT
+	wanted := 0
+	got := len(*adapter.entries)
+	if wanted != got {
+		t.Errorf("wanted %d, got %d", wanted, got)
+	}
+}
+
+func TestRecoverRepanic(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+	// ACT
+	var got any
+	func() {
+		defer func() { got = recover() }()
+		defer sut.Recover(Repanic())
+		panic("boom")
+	}()
+
+	// ASSERT
+	wanted := "boom"
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+	if len(*adapter.entries) != 1 {
+		t.Errorf("wanted 1 entry, got %d", len(*adapter.entries))
+	}
+}
+
+func TestRecoverFatal(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	exited := ""
+	sut := UsingAdapter(context.Background(), adapter).
+		WithExitBehaviour(func(_ int, msg string) { exited = msg }).
+		NewEntry()
+
+	// ACT
+	func() {
+		defer sut.Recover(RecoverLevel(Fatal))
+		panic("boom")
+	}()
+
+	// ASSERT
+	wanted := "recovered from panic: boom"
+	got := exited
+	if wanted != got {
+		t.Errorf("wanted %q, got %q", wanted, got)
+	}
+	if adapter.last().level != Fatal {
+		t.Errorf("wanted %v, got %v", Fatal, adapter.last().level)
+	}
+}
+
+func TestGo(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	done := make(chan string)
+	log := UsingAdapter(context.Background(), adapter).
+		WithExitBehaviour(func(_ int, msg string) { done <- msg })
+	ctx := ContextWithLogger(context.Background(), log)
+
+	// ACT
+	Go(ctx, func(ctx context.Context) {
+		panic("boom")
+	}, RecoverLevel(Fatal))
+
+	// ASSERT
+	wanted := "recovered from panic: boom"
+	got := <-done
+	if wanted != got {
+		t.Errorf("wanted %q, got %q", wanted, got)
+	}
+}
+
+func TestIsNul(t *testing.T) {
+	testcases := []struct {
+		name   string
+		log    Logger
+		result bool
+	}{
+		{name: "nul", log: Nul(), result: true},
+		{name: "named nul", log: Nul().Named("payments"), result: true},
+		{name: "nul with levels", log: Nul().WithLevels(NewLevels(Info)), result: true},
+		{name: "nul adapter", log: UsingAdapter(context.Background(), &nulAdapter{}), result: true},
+		{name: "other adapter", log: UsingAdapter(context.Background(), newRecordingAdapter())},
+		{name: "swappable", log: NewSwappableLogger(newRecordingAdapter())},
+		{name: "nul swappable", log: NewSwappableLogger(nil), result: true},
+		{name: "named nul swappable", log: NewSwappableLogger(nil).Named("payments"), result: true},
+		{name: "unconfigured bootstrap", log: NewBootstrapLogger(0)},
+		{name: "nul bootstrap", log: func() Logger { b := NewBootstrapLogger(0); _ = b.Configure(nil); return b }(), result: true},
+		{name: "nul sinks", log: UsingAdapter(context.Background(), &fanoutAdapter{sinks: []sink{{adapter: &nulAdapter{}}, {adapter: &nulAdapter{}}}}), result: true},
+		{name: "sinks", log: UsingAdapter(context.Background(), &fanoutAdapter{sinks: []sink{{adapter: &nulAdapter{}}, {adapter: newRecordingAdapter()}}})},
+		{name: "unsupported", log: struct{ Logger }{Nul()}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got := isNul(tc.log)
+
+			// ASSERT
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestIsNulConfigWatcher(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "unilog.json")
+	writeConfig(t, path, `{"format":"nul"}`)
+	w, err := WatchConfig(path, PollInterval(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	// ACT
+	got := isNul(w.Logger().Named("payments"))
+
+	// ASSERT
+	if !got {
+		t.Error("wanted true, got false")
+	}
+}
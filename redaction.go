@@ -0,0 +1,212 @@
+package unilog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Redaction is a strategy for replacing the value of a sensitive field.
+// The func is called with the value of the field and returns the value
+// to be logged in its place.  If the func returns false the field is
+// removed from the entry.
+type Redaction func(value any) (any, bool)
+
+// RedactionRule identifies fields to be redacted and the Redaction to
+// be applied to them.  Rules are combined in a Redactor.
+type RedactionRule struct {
+	match  func(name string, value any) bool
+	redact Redaction
+}
+
+// Redactor applies RedactionRules to the fields of a log entry.  A Redactor
+// is applied to a Logger using `WithRedactor()`, after which the fields of
+// any entry emitted by that Logger are redacted before being passed to the
+// Adapter.  This includes fields added using `WithField()`, fields added by
+// enrichment functions and fields added from the context of an error (using
+// `errorcontext`).
+//
+// Rules are applied in the order in which they are specified; the first rule
+// matching a field determines the redaction applied to it.  The rules of a
+// Redactor may be replaced at any time using `SetRules()`.  The zero value
+// is a Redactor with no rules.
+//
+// A Redactor is safe for concurrent use.
+type Redactor struct {
+	rules atomic.Value // []RedactionRule; replaced (not modified) by SetRules()
+}
+
+// NewRedactor returns a Redactor applying the specified rules.
+func NewRedactor(rules ...RedactionRule) *Redactor {
+	r := &Redactor{}
+	r.SetRules(rules...)
+	return r
+}
+
+// SetRules replaces the rules of the Redactor.  The replacement is effective
+// immediately for all Loggers using the Redactor.
+func (r *Redactor) SetRules(rules ...RedactionRule) {
+	r.rules.Store(append([]RedactionRule{}, rules...))
+}
+
+// mask is the string used to replace (or partially replace) a value.
+const mask = "********"
+
+// Mask returns a Redaction replacing a value with a fixed mask.
+func Mask() Redaction {
+	return func(any) (any, bool) { return mask, true }
+}
+
+// PartialMask returns a Redaction replacing all but the last n characters
+// of a value with '*'.  If the value has n or fewer characters it is
+// replaced entirely, as with `Mask()`.  A negative n is treated as zero.
+func PartialMask(n int) Redaction {
+	if n < 0 {
+		n = 0
+	}
+	return func(value any) (any, bool) {
+		s := []rune(fmt.Sprintf("%v", value))
+		if len(s) <= n {
+			return mask, true
+		}
+		return strings.Repeat("*", len(s)-n) + string(s[len(s)-n:]), true
+	}
+}
+
+// Remove returns a Redaction removing a field from an entry.
+func Remove() Redaction {
+	return func(any) (any, bool) { return nil, false }
+}
+
+// Hash returns a Redaction replacing a value with a (truncated) SHA-256
+// hash of the value, allowing entries with the same value to be correlated
+// without revealing the value itself.
+//
+// NOTE: values with few possible values (e.g. a PIN) may be recovered from
+// the hash by brute force; use `Mask()` or `Remove()` for such values.
+func Hash() Redaction {
+	return func(value any) (any, bool) {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%v", value)))
+		return "sha256:" + hex.EncodeToString(sum[:8]), true
+	}
+}
+
+// RedactNames returns a RedactionRule applying a specified Redaction to
+// fields with a name matching any of the specified patterns.  Patterns
+// are case-insensitive and may contain glob wildcards (as supported by
+// `path.Match()`); e.g. "*token*" matches "X-Auth-Token".
+//
+// For a field in a group (or a sub-field of a LogValuer), patterns are
+// matched against both the qualified name of the field (e.g. "db.password")
+// and the unqualified name (e.g. "password").
+func RedactNames(r Redaction, patterns ...string) RedactionRule {
+	lower := make([]string, len(patterns))
+	for i, p := range patterns {
+		lower[i] = strings.ToLower(p)
+	}
+
+	return RedactionRule{
+		match: func(name string, _ any) bool {
+			name = strings.ToLower(name)
+			leaf := name[strings.LastIndex(name, ".")+1:]
+			for _, p := range lower {
+				if match, _ := path.Match(p, name); match {
+					return true
+				}
+				if match, _ := path.Match(p, leaf); match {
+					return true
+				}
+			}
+			return false
+		},
+		redact: r,
+	}
+}
+
+// RedactValues returns a RedactionRule applying a specified Redaction to
+// fields with any value for which a specified func returns true.
+func RedactValues(r Redaction, match func(any) bool) RedactionRule {
+	return RedactionRule{
+		match:  func(_ string, value any) bool { return match(value) },
+		redact: r,
+	}
+}
+
+// RedactType returns a RedactionRule applying a specified Redaction to
+// fields with a value of type T.
+func RedactType[T any](r Redaction) RedactionRule {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return RedactionRule{
+		match: func(_ string, value any) bool {
+			return value != nil && reflect.TypeOf(value) == t
+		},
+		redact: r,
+	}
+}
+
+// MatchesRegexp returns a func for use with `RedactValues()` that returns
+// true for any value with a string representation (`%v`) matching a
+// specified regular expression.
+func MatchesRegexp(re *regexp.Regexp) func(any) bool {
+	return func(value any) bool {
+		return re.MatchString(fmt.Sprintf("%v", value))
+	}
+}
+
+// redact returns the result of applying the rules of the Redactor to a slice
+// of fields.  If the Redactor is nil or has no rules the fields are returned
+// unmodified, otherwise a new slice is returned.
+func (r *Redactor) redact(fields []Field) []Field {
+	if r == nil || len(fields) == 0 {
+		return fields
+	}
+	rules, _ := r.rules.Load().([]RedactionRule)
+	if len(rules) == 0 {
+		return fields
+	}
+
+	return redactFields(rules, "", fields)
+}
+
+// redactFields returns the result of applying rules to a slice of fields,
+// with names qualified by a prefix (identifying any group containing the
+// fields).  The fields of a Group are redacted recursively; an empty Group
+// is removed.
+func redactFields(rules []RedactionRule, prefix string, fields []Field) []Field {
+	result := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Kind == GroupKind {
+			if members := redactFields(rules, prefix+f.Key+".", f.AsGroup()); len(members) > 0 {
+				result = append(result, Group(f.Key, members...))
+			}
+			continue
+		}
+
+		value := f.Value()
+		rule := ruleFor(rules, prefix+f.Key, value)
+		if rule == nil {
+			result = append(result, f)
+			continue
+		}
+		if redacted, keep := rule.redact(value); keep {
+			result = append(result, Any(f.Key, redacted))
+		}
+	}
+	return result
+}
+
+// ruleFor returns the first of a slice of rules matching a named value, or
+// nil if no rule matches.
+func ruleFor(rules []RedactionRule, name string, value any) *RedactionRule {
+	for i := range rules {
+		if rules[i].match(name, value) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
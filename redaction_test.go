@@ -0,0 +1,184 @@
+package unilog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/blugnu/errorcontext"
+)
+
+func TestRedactions(t *testing.T) {
+	testcases := []struct {
+		name   string
+		sut    Redaction
+		value  any
+		result any
+		keep   bool
+	}{
+		{name: "mask", sut: Mask(), value: "secret", result: "********", keep: true},
+		{name: "partial mask", sut: PartialMask(4), value: "4111111111111111", result: "************1111", keep: true},
+		{name: "partial mask (short value)", sut: PartialMask(4), value: "1234", result: "********", keep: true},
+		{name: "partial mask (negative)", sut: PartialMask(-1), value: "secret", result: "******", keep: true},
+		{name: "partial mask (non-string)", sut: PartialMask(2), value: 12345, result: "***45", keep: true},
+		{name: "remove", sut: Remove(), value: "secret", result: nil, keep: false},
+		{name: "hash", sut: Hash(), value: "secret", result: "sha256:2bb80d537b1da3e3", keep: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			result, keep := tc.sut(tc.value)
+
+			// ASSERT
+			t.Run("result", func(t *testing.T) {
+				wanted := tc.result
+				got := result
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			t.Run("keep", func(t *testing.T) {
+				wanted := tc.keep
+				got := keep
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+		})
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	// ARRANGE
+	type Password string
+
+	fields := []Field{
+		String("Authorization", "Bearer abc123"),
+		String("x-api-token", "abc123"),
+		String("user", "jdoe"),
+		String("card", "4111111111111111"),
+		Any("password", Password("hunter2")),
+		Int64("count", 42),
+		Any("map", map[string]any{}),
+	}
+
+	testcases := []struct {
+		name   string
+		sut    *Redactor
+		result []Field
+	}{
+		{name: "nil redactor", sut: nil, result: fields},
+		{name: "no rules", sut: NewRedactor(), result: fields},
+		{name: "names", sut: NewRedactor(RedactNames(Mask(), "authorization", "*TOKEN*")), result: []Field{
+			Any("Authorization", "********"),
+			Any("x-api-token", "********"),
+			String("user", "jdoe"),
+			String("card", "4111111111111111"),
+			Any("password", Password("hunter2")),
+			Int64("count", 42),
+			Any("map", map[string]any{}),
+		}},
+		{name: "values", sut: NewRedactor(RedactValues(PartialMask(4), MatchesRegexp(regexp.MustCompile(`^\d{16}$`)))), result: []Field{
+			String("Authorization", "Bearer abc123"),
+			String("x-api-token", "abc123"),
+			String("user", "jdoe"),
+			Any("card", "************1111"),
+			Any("password", Password("hunter2")),
+			Int64("count", 42),
+			Any("map", map[string]any{}),
+		}},
+		{name: "type", sut: NewRedactor(RedactType[Password](Remove())), result: []Field{
+			String("Authorization", "Bearer abc123"),
+			String("x-api-token", "abc123"),
+			String("user", "jdoe"),
+			String("card", "4111111111111111"),
+			Int64("count", 42),
+			Any("map", map[string]any{}),
+		}},
+		{name: "first matching rule applies", sut: NewRedactor(RedactNames(Remove(), "user"), RedactNames(Mask(), "*")), result: []Field{
+			Any("Authorization", "********"),
+			Any("x-api-token", "********"),
+			Any("card", "********"),
+			Any("password", "********"),
+			Any("count", "********"),
+			Any("map", "********"),
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			got := tc.sut.redact(fields)
+
+			// ASSERT
+			wanted := tc.result
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestLoggerWithRedactor(t *testing.T) {
+	// ARRANGE
+	type key int
+
+	oef := enrichmentFuncs
+	defer func() { enrichmentFuncs = oef }()
+	RegisterEnrichment(func(ctx context.Context, e Enricher) Entry {
+		if token, ok := ctx.Value(key(1)).(string); ok {
+			return e.WithField("session-token", token)
+		}
+		return e.(Entry)
+	})
+
+	adapter := newRecordingAdapter()
+	root := UsingAdapter(context.Background(), adapter)
+	sut := root.WithRedactor(NewRedactor(RedactNames(Mask(), "authorization", "*token")))
+
+	testcases := []struct {
+		name   string
+		fn     func()
+		fields map[string]any
+	}{
+		{name: "with field", fn: func() { sut.NewEntry().WithField("Authorization", "Bearer abc123").Info("test") }, fields: map[string]any{"Authorization": "********"}},
+		{name: "enrichment", fn: func() { sut.WithContext(context.WithValue(context.Background(), key(1), "abc123")).Info("test") }, fields: map[string]any{"session-token": "********"}},
+		{name: "error context", fn: func() {
+			ctx := context.WithValue(context.Background(), key(1), "abc123")
+			sut.NewEntry().Error(errorcontext.Wrap(ctx, errors.New("test")))
+		}, fields: map[string]any{"session-token": "********"}},
+		{name: "original logger", fn: func() { root.NewEntry().WithField("Authorization", "Bearer abc123").Info("test") }, fields: map[string]any{"Authorization": "Bearer abc123"}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			tc.fn()
+
+			// ASSERT
+			wanted := tc.fields
+			got := adapter.last().fields
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestRedactorZeroValue(t *testing.T) {
+	// ARRANGE
+	var sut Redactor
+	adapter := newRecordingAdapter()
+	log := UsingAdapter(context.Background(), adapter).WithRedactor(&sut)
+
+	// ACT
+	log.NewEntry().With(String("a", "b")).Info("test")
+
+	// ASSERT
+	wanted := map[string]any{"a": "b"}
+	got := adapter.last().fields
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+	}
+}
@@ -0,0 +1,150 @@
+package unilog
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Scope accumulates fields describing an operation (e.g. a request) to be
+// emitted in a single, "canonical" entry when the operation is complete,
+// rather than in many entries as it progresses.
+//
+// A Scope is initialised using `BeginScope()` and is bound to a context
+// (see `Context()`), from which it may be obtained by any code holding that
+// context using `ScopeFromContext()`.  Fields are added using `Set()` and
+// `Incr()` and the entry emitted by `End()`.
+//
+// The methods of a Scope are safe for concurrent use and may be called on a
+// nil Scope (having no effect), so the result of `ScopeFromContext()` may be
+// used without checking whether the context has a Scope.
+type Scope struct {
+	mu     sync.Mutex
+	log    *logger
+	ctx    context.Context
+	name   string
+	start  time.Time
+	fields []Field
+	err    error
+	ended  bool
+}
+
+// BeginScope returns a new `Scope` with a specified name.  When the Scope
+// ends, an entry is emitted with the name as the message, using an entry
+// initialised from the receiver.  If the receiver has no context, the Scope
+// is bound to a context derived from `context.Background()`.
+func (log *logger) BeginScope(name string) *Scope {
+	ctx := log.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	scope := &Scope{log: log, name: name, start: time.Now()}
+	scope.ctx = context.WithValue(ctx, scopeContextKey, scope)
+	return scope
+}
+
+// ScopeFromContext returns the `Scope` bound to a specified context (or a
+// context derived from it), or nil if the context has no Scope.
+func ScopeFromContext(ctx context.Context) *Scope {
+	if ctx == nil {
+		return nil
+	}
+	scope, _ := ctx.Value(scopeContextKey).(*Scope)
+	return scope
+}
+
+// Context returns a new context, derived from the context of the entry from
+// which the Scope was initialised, to which the Scope is bound.
+func (s *Scope) Context() context.Context {
+	if s == nil {
+		return nil
+	}
+	return s.ctx
+}
+
+// Set sets a named value in the scope, replacing any existing value with the
+// same name.
+func (s *Scope) Set(name string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ended {
+		s.fields = setField(s.fields, Any(name, value))
+	}
+}
+
+// Incr increments a named counter in the scope.  If the counter has not been
+// set it is set to 1.  If a value other than a counter has been set with
+// the same name, it is replaced by a counter.
+func (s *Scope) Incr(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+	for i, f := range s.fields {
+		if f.Key == name && f.Kind == Int64Kind {
+			s.fields[i].num++
+			return
+		}
+	}
+	s.fields = setField(s.fields, Int64(name, 1))
+}
+
+// Fail records an error as the outcome of the scope.  If called more than
+// once, the most recent error is recorded.  A nil error has no effect.
+func (s *Scope) Fail(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ended {
+		s.err = err
+	}
+}
+
+// End emits an entry with the accumulated fields of the scope and:
+//
+//	duration  the time since the scope began
+//	status    "ok", or "error" if an error was recorded (see `Fail()`)
+//	error     the error recorded (if any)
+//
+// The entry is emitted at `Info` level, or `Error` level if an error was
+// recorded, with any context in the error applied (see `errorcontext`).
+//
+// Only the first call to End emits an entry; values set after the scope
+// has ended are ignored.
+func (s *Scope) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	duration := time.Since(s.start)
+	fields := s.fields
+	err := s.err
+	s.mu.Unlock()
+
+	if err == nil {
+		s.log.withFields(append(fields, Duration("duration", duration), String("status", "ok"))).
+			Emit(Info, s.name)
+		return
+	}
+
+	s.log.withFields(append(fields, Duration("duration", duration), String("status", "error"), Err(err))).
+		entryFromArgs(err).
+		Emit(Error, s.name)
+}
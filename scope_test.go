@@ -0,0 +1,172 @@
+package unilog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScope(t *testing.T) {
+	testcases := []struct {
+		name   string
+		exec   func(*Scope)
+		level  Level
+		fields map[string]any
+	}{
+		{name: "ok",
+			exec: func(s *Scope) {
+				s.Set("method", "GET")
+				s.Incr("db.queries")
+				s.Incr("db.queries")
+				s.Set("method", "PUT")
+			},
+			level:  Info,
+			fields: map[string]any{"method": "PUT", "db.queries": int64(2), "status": "ok"},
+		},
+		{name: "error",
+			exec: func(s *Scope) {
+				s.Set("method", "GET")
+				s.Fail(nil)
+				s.Fail(errors.New("failed"))
+			},
+			level:  Error,
+			fields: map[string]any{"method": "GET", "status": "error", "error": errors.New("failed")},
+		},
+		{name: "set replaced by counter",
+			exec: func(s *Scope) {
+				s.Set("retries", "none")
+				s.Incr("retries")
+			},
+			level:  Info,
+			fields: map[string]any{"retries": int64(1), "status": "ok"},
+		},
+		{name: "from context",
+			exec: func(s *Scope) {
+				ctx := context.WithValue(s.Context(), contextKey(99), "derived")
+				ScopeFromContext(ctx).Set("user", "alice")
+			},
+			level:  Info,
+			fields: map[string]any{"user": "alice", "status": "ok"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			sut := UsingAdapter(context.Background(), adapter).WithContext(context.Background()).BeginScope("request")
+
+			// ACT
+			tc.exec(sut)
+			sut.End()
+			sut.End()
+
+			// ASSERT
+			if len(*adapter.entries) != 1 {
+				t.Fatalf("wanted 1 entry, got %d", len(*adapter.entries))
+			}
+			entry := adapter.last()
+
+			if entry.level != tc.level || entry.s != "request" {
+				t.Errorf("wanted %v: request, got %v: %s", tc.level, entry.level, entry.s)
+			}
+
+			if _, ok := entry.fields["duration"].(time.Duration); !ok {
+				t.Errorf("wanted duration field, got %#v", entry.fields["duration"])
+			}
+			delete(entry.fields, "duration")
+
+			wanted := tc.fields
+			got := entry.fields
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestScopeAfterEnd(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).WithContext(context.Background()).BeginScope("request")
+	sut.End()
+
+	// ACT
+	sut.Set("key", "value")
+	sut.Incr("count")
+	sut.Fail(errors.New("failed"))
+	sut.End()
+
+	// ASSERT
+	if len(*adapter.entries) != 1 {
+		t.Errorf("wanted 1 entry, got %d", len(*adapter.entries))
+	}
+}
+
+func TestScopeWithoutContext(t *testing.T) {
+	testcases := []struct {
+		name  string
+		entry func() Entry
+	}{
+		{name: "nul", entry: func() Entry { return Nul().NewEntry() }},
+		{name: "default", entry: func() Entry { return Default().NewEntry() }},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			sut := tc.entry().BeginScope("request")
+			sut.Set("key", "value")
+			sut.End()
+
+			// ASSERT
+			wanted := sut
+			got := ScopeFromContext(sut.Context())
+			if wanted != got {
+				t.Errorf("wanted %p, got %p", wanted, got)
+			}
+		})
+	}
+}
+
+func TestScopeNil(t *testing.T) {
+	// ARRANGE
+	sut := ScopeFromContext(context.Background())
+
+	// ACT
+	sut.Set("key", "value")
+	sut.Incr("count")
+	sut.Fail(errors.New("failed"))
+	sut.End()
+
+	// ASSERT
+	if sut != nil || sut.Context() != nil {
+		t.Errorf("wanted nil scope")
+	}
+}
+
+func TestScopeConcurrentUse(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).WithContext(context.Background()).BeginScope("request")
+	wg := sync.WaitGroup{}
+
+	// ACT
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ScopeFromContext(sut.Context()).Incr("count")
+		}()
+	}
+	wg.Wait()
+	sut.End()
+
+	// ASSERT
+	wanted := int64(10)
+	got := adapter.last().fields["count"]
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
@@ -0,0 +1,285 @@
+package unilog
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Detector is the interface implemented by types that identify sensitive
+// content in the message of a log entry.
+//
+// Detect returns the byte offsets of each occurrence of sensitive content
+// in a string, as pairs of [start, end) offsets (in the same form as
+// returned by `regexp.FindAllStringIndex()`).  Offsets outside the string
+// are clamped to it; any other pair that does not identify content in the
+// string (e.g. with end <= start) is ignored.
+type Detector interface {
+	Detect(s string) [][]int
+}
+
+// DetectorFunc is a func implementing the Detector interface.
+type DetectorFunc func(s string) [][]int
+
+// Detect implements the Detector interface by calling the func.
+func (fn DetectorFunc) Detect(s string) [][]int {
+	return fn(s)
+}
+
+// Scrubber masks sensitive content identified by one or more Detectors
+// in the message of a log entry.  A Scrubber is applied to a Logger using
+// `WithScrubber()`, after which the message of every entry emitted by that
+// Logger is scrubbed before being passed to the Adapter.
+//
+// Scrubbing is applied to the final message string, i.e. after formatting
+// by `Infof()`, `Errorf()` etc.
+type Scrubber struct {
+	detectors []Detector
+}
+
+// NewScrubber returns a Scrubber using the specified Detectors.  If no
+// Detectors are specified the Scrubber uses `DefaultDetectors()`.
+func NewScrubber(detectors ...Detector) *Scrubber {
+	if len(detectors) == 0 {
+		detectors = DefaultDetectors()
+	}
+	return &Scrubber{detectors: detectors}
+}
+
+// DefaultDetectors returns a new slice of all Detectors provided by
+// `unilog`.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		EmailDetector(),
+		CardNumberDetector(),
+		BearerTokenDetector(),
+		JWTDetector(),
+		IPAddressDetector(),
+	}
+}
+
+// Scrub returns a string with any content identified by the Detectors
+// of the Scrubber replaced with a mask.  If the Scrubber is nil the string
+// is returned unmodified.
+func (sc *Scrubber) Scrub(s string) string {
+	if sc == nil {
+		return s
+	}
+
+	var found [][]int
+	for _, d := range sc.detectors {
+		for _, loc := range d.Detect(s) {
+			if len(loc) < 2 {
+				continue
+			}
+			start, end := loc[0], loc[1]
+			if start < 0 {
+				start = 0
+			}
+			if end > len(s) {
+				end = len(s)
+			}
+			if start < end {
+				found = append(found, []int{start, end})
+			}
+		}
+	}
+	if len(found) == 0 {
+		return s
+	}
+
+	// sort occurrences by start offset, so that overlapping occurrences
+	// (e.g. a JWT used as a bearer token) can be merged
+	sort.Slice(found, func(i, j int) bool { return found[i][0] < found[j][0] })
+
+	sb := strings.Builder{}
+	pos := 0
+	for _, loc := range found {
+		start, end := loc[0], loc[1]
+		if end <= pos {
+			continue // contained in an occurrence already masked
+		}
+		if start >= pos {
+			sb.WriteString(s[pos:start])
+			sb.WriteString(mask)
+		}
+		pos = end
+	}
+	sb.WriteString(s[pos:])
+
+	return sb.String()
+}
+
+var (
+	emailPattern       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	cardNumberPattern  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,}\b`)
+	bearerTokenPattern = regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`)
+	jwtPattern         = regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	ipv4Pattern        = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern        = regexp.MustCompile(`[0-9A-Fa-f:.]*:[0-9A-Fa-f:.]*`)
+)
+
+// EmailDetector returns a Detector identifying email addresses.
+func EmailDetector() Detector {
+	return DetectorFunc(func(s string) [][]int {
+		return emailPattern.FindAllStringIndex(s, -1)
+	})
+}
+
+// CardNumberDetector returns a Detector identifying payment card numbers;
+// sequences of 13 to 19 digits (optionally separated by spaces or hyphens)
+// that pass the Luhn checksum.  A card number adjacent to other groups of
+// digits (e.g. "4111-1111-1111-1111 99") is identified without them.
+func CardNumberDetector() Detector {
+	return DetectorFunc(func(s string) [][]int {
+		var result [][]int
+		for _, loc := range cardNumberPattern.FindAllStringIndex(s, -1) {
+			result = append(result, cardNumbers(s, loc[0], loc[1])...)
+		}
+		return result
+	})
+}
+
+// cardNumbers returns the spans of card numbers in a run of digit groups in
+// a string, between specified offsets.  Starting with the first group, the
+// longest sequence of whole groups with 13 to 19 digits passing the Luhn
+// checksum is identified; the search continues with the group following any
+// card number identified, or else the next group.
+func cardNumbers(s string, start, end int) [][]int {
+	// the offsets of the start and end of each group of digits
+	var starts, ends []int
+	for i := start; i < end; i++ {
+		if !isDigit(s[i]) {
+			continue
+		}
+		if i == start || !isDigit(s[i-1]) {
+			starts = append(starts, i)
+		}
+		if i+1 == end || !isDigit(s[i+1]) {
+			ends = append(ends, i+1)
+		}
+	}
+
+	var result [][]int
+	for i := 0; i < len(starts); i++ {
+		for j := len(ends) - 1; j >= i; j-- {
+			candidate := s[starts[i]:ends[j]]
+			if n := countDigits(candidate); n >= 13 && n <= 19 && luhn(candidate) {
+				result = append(result, []int{starts[i], ends[j]})
+				i = j
+				break
+			}
+		}
+	}
+	return result
+}
+
+// isDigit returns true if a byte is an ASCII digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// countDigits returns the number of ASCII digits in a string.
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+// BearerTokenDetector returns a Detector identifying the token in a bearer
+// token authorization (e.g. "Bearer abc123"); the "Bearer" scheme itself is
+// not identified.
+func BearerTokenDetector() Detector {
+	return DetectorFunc(func(s string) [][]int {
+		matches := bearerTokenPattern.FindAllStringSubmatchIndex(s, -1)
+		result := make([][]int, 0, len(matches))
+		for _, m := range matches {
+			result = append(result, m[2:4])
+		}
+		return result
+	})
+}
+
+// JWTDetector returns a Detector identifying JSON Web Tokens.
+func JWTDetector() Detector {
+	return DetectorFunc(func(s string) [][]int {
+		return jwtPattern.FindAllStringIndex(s, -1)
+	})
+}
+
+// IPAddressDetector returns a Detector identifying IPv4 and IPv6 addresses.
+//
+// An IPv6 address is identified only if it is delimited by characters other
+// than letters, digits or '_' and has at least one hex group; e.g. "::" and
+// the separators in "std::string" or "README::Usage" are not identified.
+func IPAddressDetector() Detector {
+	return DetectorFunc(func(s string) [][]int {
+		var result [][]int
+		for _, loc := range ipv4Pattern.FindAllStringIndex(s, -1) {
+			if net.ParseIP(s[loc[0]:loc[1]]) != nil {
+				result = append(result, loc)
+			}
+		}
+		for _, loc := range ipv6Pattern.FindAllStringIndex(s, -1) {
+			if loc, ok := ipv6Address(s, loc[0], loc[1]); ok {
+				result = append(result, loc)
+			}
+		}
+		return result
+	})
+}
+
+// ipv6Address returns the offsets of an IPv6 address in a candidate run of
+// hex digits, colons and dots in a string, if the run (excluding any
+// trailing punctuation) is a delimited IPv6 address with a hex group.
+func ipv6Address(s string, start, end int) ([]int, bool) {
+	if start > 0 && isWordByte(s[start-1]) || end < len(s) && isWordByte(s[end]) {
+		return nil, false
+	}
+
+	// a candidate may end with punctuation, e.g. "from fe80::1." or "fe80::1:"
+	candidate := strings.TrimRight(s[start:end], ".")
+	if strings.HasSuffix(candidate, ":") && !strings.HasSuffix(candidate, "::") {
+		candidate = candidate[:len(candidate)-1]
+	}
+
+	if strings.Trim(candidate, ":") == "" || net.ParseIP(candidate) == nil {
+		return nil, false
+	}
+	return []int{start, start + len(candidate)}, true
+}
+
+// isWordByte returns true if a byte is an ASCII letter or digit, '_' or part
+// of a multi-byte (UTF-8) character.
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 ||
+		b >= '0' && b <= '9' || b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z'
+}
+
+// luhn returns true if the digits in a string pass the Luhn checksum.
+// Any non-digit characters are ignored.
+func luhn(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
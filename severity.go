@@ -0,0 +1,119 @@
+package unilog
+
+import "sort"
+
+// SeverityMap maps Levels to the severities of a logging package (or other
+// backend), of any type T.  Adapters use a SeverityMap to translate any
+// Level, including custom levels (see `RegisterLevel()`), into a backend
+// specific severity (see `Severity()`).
+//
+// A SeverityMap is immutable; `With()` returns a new SeverityMap with an
+// additional mapping.
+type SeverityMap[T any] struct {
+	severities map[Level]T
+	levels     []Level // the mapped levels, most severe first
+}
+
+// NewSeverityMap returns a SeverityMap with the specified mappings.
+func NewSeverityMap[T any](severities map[Level]T) *SeverityMap[T] {
+	m := &SeverityMap[T]{severities: make(map[Level]T, len(severities))}
+	for lv, sev := range severities {
+		m.severities[lv] = sev
+	}
+	m.sort()
+	return m
+}
+
+// sort initialises the levels of the map, most severe first.
+func (m *SeverityMap[T]) sort() {
+	m.levels = make([]Level, 0, len(m.severities))
+	for lv := range m.severities {
+		m.levels = append(m.levels, lv)
+	}
+	sort.Slice(m.levels, func(i, j int) bool { return m.levels[i].rank() < m.levels[j].rank() })
+}
+
+// With returns a new SeverityMap with the mappings of the receiver and a
+// mapping of a specified level to a specified severity, replacing any
+// existing mapping for the level.
+func (m *SeverityMap[T]) With(level Level, severity T) *SeverityMap[T] {
+	result := &SeverityMap[T]{severities: make(map[Level]T, len(m.severities)+1)}
+	for lv, sev := range m.severities {
+		result.severities[lv] = sev
+	}
+	result.severities[level] = severity
+	result.sort()
+	return result
+}
+
+// Severity returns the severity mapped to a specified level.  If the level is
+// not mapped, the severity of the least severe mapped level that is at least
+// as severe as the level is returned (so that an entry is never reported as
+// less severe than it is); if there is no such level the severity of the
+// most severe mapped level is returned.
+//
+// If the map is empty, the zero value of T is returned.
+func (m *SeverityMap[T]) Severity(level Level) T {
+	if sev, ok := m.severities[level]; ok {
+		return sev
+	}
+
+	var result T
+	if len(m.levels) == 0 {
+		return result
+	}
+
+	result = m.severities[m.levels[0]]
+	rank := level.rank()
+	for _, lv := range m.levels {
+		if lv.rank() > rank {
+			break
+		}
+		result = m.severities[lv]
+	}
+	return result
+}
+
+// SyslogSeverities returns a SeverityMap for the syslog severities defined
+// by RFC 5424 (0 = Emergency to 7 = Debug).  `Panic` is mapped to Alert (1),
+// `Fatal` to Critical (2) and `Trace` to Debug (7).
+func SyslogSeverities() *SeverityMap[int] {
+	return NewSeverityMap(map[Level]int{
+		Panic: 1,
+		Fatal: 2,
+		Error: 3,
+		Warn:  4,
+		Info:  6,
+		Debug: 7,
+		Trace: 7,
+	})
+}
+
+// OTelSeverities returns a SeverityMap for OpenTelemetry SeverityNumber
+// values (1 = TRACE to 24 = FATAL4).  `Panic` is mapped to FATAL2 (22).
+func OTelSeverities() *SeverityMap[int] {
+	return NewSeverityMap(map[Level]int{
+		Panic: 22,
+		Fatal: 21,
+		Error: 17,
+		Warn:  13,
+		Info:  9,
+		Debug: 5,
+		Trace: 1,
+	})
+}
+
+// GCPSeverities returns a SeverityMap for Google Cloud Logging LogSeverity
+// names.  `Panic` is mapped to "ALERT", `Fatal` to "CRITICAL" and `Trace`
+// to "DEBUG".
+func GCPSeverities() *SeverityMap[string] {
+	return NewSeverityMap(map[Level]string{
+		Panic: "ALERT",
+		Fatal: "CRITICAL",
+		Error: "ERROR",
+		Warn:  "WARNING",
+		Info:  "INFO",
+		Debug: "DEBUG",
+		Trace: "DEBUG",
+	})
+}
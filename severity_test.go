@@ -0,0 +1,68 @@
+package unilog
+
+import "testing"
+
+func TestSeverityMap(t *testing.T) {
+	withCustomLevels(t, func() {
+		// ARRANGE
+		notice, _ := RegisterLevel("Notice", Info)
+		critical, _ := RegisterLevel("Critical", Error)
+		emergency, _ := RegisterLevel("Emergency", Panic)
+		syslog := SyslogSeverities().With(notice, 5)
+
+		testcases := []struct {
+			name   string
+			sut    *SeverityMap[int]
+			level  Level
+			result int
+		}{
+			{name: "syslog/info", sut: syslog, level: Info, result: 6},
+			{name: "syslog/notice (mapped)", sut: syslog, level: notice, result: 5},
+			{name: "syslog/critical (unmapped)", sut: syslog, level: critical, result: 2},
+			{name: "syslog/emergency (unmapped, most severe)", sut: syslog, level: emergency, result: 1},
+			{name: "syslog/notice (original map unmodified)", sut: SyslogSeverities(), level: notice, result: 4},
+			{name: "otel/trace", sut: OTelSeverities(), level: Trace, result: 1},
+			{name: "otel/panic", sut: OTelSeverities(), level: Panic, result: 22},
+			{name: "empty", sut: NewSeverityMap(map[Level]int{}), level: Info, result: 0},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				// ACT
+				got := tc.sut.Severity(tc.level)
+
+				// ASSERT
+				wanted := tc.result
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+		}
+	})
+}
+
+func TestGCPSeverities(t *testing.T) {
+	testcases := []struct {
+		level  Level
+		result string
+	}{
+		{level: Panic, result: "ALERT"},
+		{level: Fatal, result: "CRITICAL"},
+		{level: Error, result: "ERROR"},
+		{level: Warn, result: "WARNING"},
+		{level: Info, result: "INFO"},
+		{level: Debug, result: "DEBUG"},
+		{level: Trace, result: "DEBUG"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			// ACT
+			got := GCPSeverities().Severity(tc.level)
+
+			// ASSERT
+			wanted := tc.result
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+//go:build !windows
+
+package unilog
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// HandleSignals handles signals controlling the root level of a specified
+// `Levels` and files written by Loggers, until the returned func is called:
+//
+//	SIGUSR1  increases verbosity by one level; after Trace, the root level
+//	         returns to that in effect when HandleSignals was called
+//	SIGUSR2  restores the root level in effect when HandleSignals was called
+//	SIGHUP   reopens files (see `ReopenFiles()`), e.g. after log rotation
+//
+// Each change is reported by an entry emitted using a specified Logger,
+// regardless of the root level.  If the Levels is nil, SIGUSR1 and SIGUSR2
+// are not handled.
+//
+// Handling signals is opt-in, and is not supported on Windows:
+//
+//	stop := unilog.HandleSignals(logger, levels)
+//	defer stop()
+func HandleSignals(log Logger, levels *Levels) (stop func()) {
+	sigs := []os.Signal{syscall.SIGHUP}
+	if levels != nil {
+		sigs = append(sigs, syscall.SIGUSR1, syscall.SIGUSR2)
+	}
+
+	h := &signalHandler{
+		// entries reporting changes are emitted regardless of the root level
+		log:     log.WithContext(ContextWithLevel(context.Background(), Info)),
+		levels:  levels,
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+	if levels != nil {
+		h.initial = levels.Level("")
+	}
+	signal.Notify(h.signals, sigs...)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		h.run()
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(h.signals)
+			close(h.done)
+			wg.Wait()
+		})
+	}
+}
+
+// signalHandler handles the signals of `HandleSignals()`.
+type signalHandler struct {
+	log     Entry
+	levels  *Levels
+	initial Level
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// run handles signals until the handler is stopped.
+func (h *signalHandler) run() {
+	for {
+		select {
+		case sig := <-h.signals:
+			h.handle(sig)
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// handle handles a signal.
+func (h *signalHandler) handle(sig os.Signal) {
+	entry := h.log.With(String("signal", sig.String()))
+
+	switch sig {
+	case syscall.SIGUSR1:
+		level := h.moreVerbose(h.levels.Level(""))
+		h.levels.Set("", level)
+		entry.With(String("level", levelName(level))).Info("root level changed")
+
+	case syscall.SIGUSR2:
+		h.levels.Set("", h.initial)
+		entry.With(String("level", levelName(h.initial))).Info("root level restored")
+
+	case syscall.SIGHUP:
+		n, err := reopenFiles()
+		if err != nil {
+			entry.With(Int64("files", int64(n)), Err(err)).Error("files reopened")
+			return
+		}
+		entry.With(Int64("files", int64(n))).Info("files reopened")
+	}
+}
+
+// moreVerbose returns the least verbose level that is more verbose than a
+// specified level or, if there is none, the initial level.
+func (h *signalHandler) moreVerbose(level Level) Level {
+	result, found := h.initial, false
+	for _, lv := range allLevels() {
+		if lv.rank() > level.rank() && (!found || lv.rank() < result.rank()) {
+			result, found = lv, true
+		}
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+//go:build !windows
+
+package unilog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// signalAndWait sends a signal to the test process and waits until a condition
+// is met.
+func signalAndWait(t *testing.T, sig syscall.Signal, cond func() bool) {
+	t.Helper()
+	if err := syscall.Kill(syscall.Getpid(), sig); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %v to be handled", sig)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHandleSignals(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	rotated := filepath.Join(dir, "app.log.1")
+
+	log, err := Open("logfmt+file://" + path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer closeAdapter(log.(*logger).Adapter)
+
+	levels := NewLevels(Info)
+	log = log.WithLevels(levels)
+	stop := HandleSignals(log, levels)
+	defer stop()
+
+	entries := func(path string) int {
+		data, _ := os.ReadFile(path)
+		return strings.Count(string(data), "\n")
+	}
+
+	t.Run("SIGUSR1", func(t *testing.T) {
+		for _, level := range []Level{Debug, Trace, Info} {
+			// ARRANGE
+			n := entries(path)
+
+			// ACT
+			signalAndWait(t, syscall.SIGUSR1, func() bool { return levels.Level("") == level && entries(path) > n })
+
+			// ASSERT
+			lines := readLines(t, path)
+			wanted := `level=info msg="root level changed" signal="user defined signal 1" level=` + levelName(level)
+			got := lines[len(lines)-1]
+			if !strings.Contains(got, wanted) {
+				t.Errorf("\nwanted %s\ngot    %s", wanted, got)
+			}
+		}
+	})
+
+	t.Run("SIGUSR2", func(t *testing.T) {
+		// ARRANGE
+		signalAndWait(t, syscall.SIGUSR1, func() bool { return levels.Level("") == Debug })
+		n := entries(path)
+
+		// ACT
+		signalAndWait(t, syscall.SIGUSR2, func() bool { return levels.Level("") == Info && entries(path) > n })
+
+		// ASSERT
+		lines := readLines(t, path)
+		wanted := `msg="root level restored" signal="user defined signal 2" level=info`
+		got := lines[len(lines)-1]
+		if !strings.Contains(got, wanted) {
+			t.Errorf("\nwanted %s\ngot    %s", wanted, got)
+		}
+	})
+
+	t.Run("SIGHUP", func(t *testing.T) {
+		// ARRANGE
+		if err := os.Rename(path, rotated); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// ACT
+		signalAndWait(t, syscall.SIGHUP, func() bool { return entries(path) > 0 })
+
+		// ASSERT
+		lines := readLines(t, path)
+		wanted := `msg="files reopened" signal=hangup files=1`
+		if len(lines) != 1 || !strings.Contains(lines[0], wanted) {
+			t.Errorf("\nwanted %s\ngot    %s", wanted, strings.Join(lines, "\n"))
+		}
+	})
+}
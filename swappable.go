@@ -0,0 +1,216 @@
+package unilog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// SwappableLogger is a `Logger` with an `Adapter` that may be replaced at any
+// time.  Any replacement is effective immediately for the SwappableLogger and
+// all Loggers and Entries initialised from it, including those captured by
+// modules before the replacement.
+//
+// This allows a Logger to be supplied to modules during initialisation (e.g.
+// using `Nul()`) and the Adapter replaced once configuration is complete
+// (e.g. after flags have been parsed):
+//
+//	var logger = unilog.NewSwappableLogger(nil) // Nul() until replaced
+//
+//	func main() {
+//		foo.Logger = logger
+//		flag.Parse()
+//		if logEnabled {
+//			logger.Use(unilog.StdLog())
+//		}
+//	}
+//
+// A SwappableLogger is safe for concurrent use.
+type SwappableLogger struct {
+	Logger
+	adapter *swappableAdapter
+}
+
+// NewSwappableLogger returns a new `SwappableLogger` using a specified
+// `Adapter`.  If the Adapter is nil, entries are discarded (as with `Nul()`)
+// until the Adapter is replaced.
+func NewSwappableLogger(adapter Adapter) *SwappableLogger {
+	a := &swappableAdapter{}
+	a.store(adapter)
+	return &SwappableLogger{
+		Logger:  UsingAdapter(context.Background(), a),
+		adapter: a,
+	}
+}
+
+// Swap replaces the `Adapter` of the logger, returning the replaced Adapter.
+// A nil Adapter discards all entries (as with `Nul()`).  An Adapter emitting
+// entries to the logger (e.g. that of the logger itself) would form a cycle
+// and is not used; the current Adapter is returned.
+//
+// Swap returns once any entries being emitted using the replaced Adapter are
+// complete, so the replaced Adapter may then be closed safely.
+func (sl *SwappableLogger) Swap(adapter Adapter) Adapter {
+	replaced, _ := sl.adapter.swap(adapter)
+	return replaced
+}
+
+// Use replaces the `Adapter` of the logger with that of a specified Logger
+// (e.g. `StdLog()`), returning the replaced Adapter.  Only the Adapter is
+// used; any other configuration of the specified Logger (e.g. redaction) is
+// not applied.
+//
+// The specified Logger must be initialised by this package (e.g. using
+// `UsingAdapter()`), or be a `SwappableLogger` or `BootstrapLogger` (or a
+// type embedding one), otherwise an error is returned.  An error is also
+// returned if the specified Logger emits entries to the logger (e.g. a
+// SwappableLogger using the logger), which would form a cycle.  In either
+// case the Adapter is not replaced.  Specifying the logger itself has no
+// effect.
+func (sl *SwappableLogger) Use(log Logger) (Adapter, error) {
+	al, ok := log.(adapterLogger)
+	if !ok {
+		return nil, fmt.Errorf("unilog: unsupported logger: %T", log)
+	}
+	return sl.adapter.swap(al.loggerAdapter())
+}
+
+// loggerAdapter returns the Adapter to which the logger emits entries.
+func (sl *SwappableLogger) loggerAdapter() Adapter {
+	return sl.adapter
+}
+
+// adapterLogger is implemented by the Loggers of this package (and any type
+// embedding them), providing the Adapter to which entries are emitted.
+type adapterLogger interface {
+	loggerAdapter() Adapter
+}
+
+// swapMu serialises the replacement of the Adapters of all SwappableLoggers
+// and the configuration of all BootstrapLoggers, so that concurrent changes
+// cannot form a cycle.
+var swapMu sync.Mutex
+
+// emitsTo returns true if an Adapter is, or emits entries to, a specified
+// target Adapter, directly or through any SwappableLogger or configured
+// BootstrapLogger.
+func emitsTo(adapter Adapter, target Adapter) bool {
+	if adapter == target {
+		return true
+	}
+	switch a := adapter.(type) {
+	case *swappableAdapter:
+		return emitsTo(a.current(), target)
+	case *bootstrapAdapter:
+		return emitsTo(a.configured(), target)
+	default:
+		return false
+	}
+}
+
+// adapterValue wraps an Adapter for storage in an atomic.Value, which requires
+// a consistent concrete type.
+type adapterValue struct {
+	Adapter
+}
+
+// swappableAdapter is an `Adapter` delegating to a replaceable Adapter.
+type swappableAdapter struct {
+	mu    sync.RWMutex // held (read) while emitting; replacing waits for emits to complete
+	value atomic.Value // adapterValue
+}
+
+// current returns the current Adapter.
+func (a *swappableAdapter) current() Adapter {
+	return a.value.Load().(adapterValue).Adapter
+}
+
+// swap replaces the current Adapter, returning the replaced Adapter.  If the
+// Adapter is the receiver the current Adapter is returned; if the Adapter
+// emits entries to the receiver the current Adapter is returned with an
+// error.
+func (a *swappableAdapter) swap(adapter Adapter) (Adapter, error) {
+	swapMu.Lock()
+	defer swapMu.Unlock()
+
+	switch {
+	case adapter == Adapter(a):
+		return a.current(), nil
+	case emitsTo(adapter, a):
+		return a.current(), fmt.Errorf("unilog: cycle: %T emits entries to the swappable logger", adapter)
+	default:
+		return a.store(adapter), nil
+	}
+}
+
+// store replaces the current Adapter, returning the replaced Adapter (if any)
+// once any entries being emitted using it are complete.
+func (a *swappableAdapter) store(adapter Adapter) Adapter {
+	if adapter == nil {
+		adapter = &nulAdapter{}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	old, _ := a.value.Swap(adapterValue{adapter}).(adapterValue)
+	return old.Adapter
+}
+
+// Emit emits an entry with no fields using the current Adapter.
+func (a *swappableAdapter) Emit(level Level, s string) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	a.current().Emit(level, s)
+}
+
+// EmitFields emits an entry with specified fields using the current Adapter.
+func (a *swappableAdapter) EmitFields(level Level, s string, fields []Field) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	emitFields(a.current(), level, s, fields)
+}
+
+// Enabled returns true if the current Adapter emits entries at a specified
+// level.
+func (a *swappableAdapter) Enabled(level Level) bool {
+	return adapterEnabled(a.current(), level)
+}
+
+// NewEntry returns the receiver; fields are held by the Logger, not the
+// Adapter.
+func (a *swappableAdapter) NewEntry() Adapter {
+	return a
+}
+
+// WithField returns a new entry of the current Adapter with a specified
+// field.  The result is not swappable.
+func (a *swappableAdapter) WithField(name string, value any) Adapter {
+	return a.current().WithField(name, value)
+}
+
+// emitFields emits an entry with specified fields using a specified Adapter.
+// If the Adapter does not implement `FieldAdapter`, each field is added to a
+// new entry of the Adapter using `WithField()` (with the fields of any group
+// qualified by the group name).
+func emitFields(adapter Adapter, level Level, s string, fields []Field) {
+	if fa, ok := adapter.(FieldAdapter); ok {
+		fa.EmitFields(level, s, fields)
+		return
+	}
+
+	if len(fields) > 0 {
+		adapter = adapter.NewEntry()
+		for _, f := range Flatten(fields) {
+			adapter = adapter.WithField(f.Key, f.Value())
+		}
+	}
+	adapter.Emit(level, s)
+}
+
+// adapterEnabled returns true if a specified Adapter emits entries at a
+// specified level; i.e. if the Adapter does not implement `LevelEnabler` or
+// is enabled at the level.
+func adapterEnabled(adapter Adapter, level Level) bool {
+	le, ok := adapter.(LevelEnabler)
+	return !ok || le.Enabled(level)
+}
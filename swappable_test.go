@@ -0,0 +1,263 @@
+package unilog
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSwappableLogger(t *testing.T) {
+	// ARRANGE
+	sut := NewSwappableLogger(nil)
+	entry := sut.NewEntry().WithValues("id", 42)
+	named := sut.Named("module")
+
+	// ACT
+	entry.Info("discarded")
+
+	recording := newRecordingAdapter()
+	sut.Swap(recording)
+	entry.Info("entry")
+	named.NewEntry().WithGroup("db").WithValues("table", "users").Warn("named")
+
+	fields := &fieldAdapter{level: Trace}
+	replaced := sut.Swap(fields)
+	entry.Debug("fields")
+
+	// ASSERT
+	t.Run("plain adapter", func(t *testing.T) {
+		wanted := []recordedEntry{
+			{level: Info, s: "entry", fields: map[string]any{"id": 42}},
+			{level: Warn, s: "named", fields: map[string]any{"logger": "module", "db.table": "users"}},
+		}
+		got := *recording.entries
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v", wanted, got)
+		}
+	})
+
+	t.Run("replaced adapter", func(t *testing.T) {
+		wanted := Adapter(recording)
+		got := replaced
+		if wanted != got {
+			t.Errorf("wanted %#v, got %#v", wanted, got)
+		}
+	})
+
+	t.Run("field adapter", func(t *testing.T) {
+		wanted := []Field{Any("id", 42)}
+		got := fields.fields
+		if fields.calls != 1 || !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %#v\ngot    %#v (%d calls)", wanted, got, fields.calls)
+		}
+	})
+
+	t.Run("level enabler", func(t *testing.T) {
+		fields.level = Info
+		entry.Debug("not enabled")
+		if fields.calls != 1 {
+			t.Errorf("wanted 1 call, got %d", fields.calls)
+		}
+	})
+}
+
+func TestSwappableLoggerUse(t *testing.T) {
+	// ARRANGE
+	recording := newRecordingAdapter()
+	other := NewSwappableLogger(recording)
+	bootstrap := NewBootstrapLogger(0)
+
+	testcases := []struct {
+		name   string
+		log    Logger
+		result Adapter
+		err    bool
+	}{
+		{name: "logger", log: UsingAdapter(context.Background(), recording), result: recording},
+		{name: "named logger", log: UsingAdapter(context.Background(), recording).Named("payments"), result: recording},
+		{name: "swappable", log: other, result: other.adapter},
+		{name: "embedded swappable", log: struct{ *SwappableLogger }{other}, result: other.adapter},
+		{name: "bootstrap", log: bootstrap, result: bootstrap.adapter},
+		{name: "embedded bootstrap", log: struct{ *BootstrapLogger }{bootstrap}, result: bootstrap.adapter},
+		{name: "unsupported", log: struct{ Logger }{Nul()}, result: &nulAdapter{}, err: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			sut := NewSwappableLogger(nil)
+
+			// ACT
+			_, err := sut.Use(tc.log)
+
+			// ASSERT
+			if tc.err != (err != nil) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			wanted := tc.result
+			got := sut.adapter.current()
+			if !reflect.DeepEqual(wanted, got) {
+				t.Errorf("wanted %#v, got %#v", wanted, got)
+			}
+		})
+	}
+
+	t.Run("self", func(t *testing.T) {
+		// ARRANGE
+		sut := NewSwappableLogger(recording)
+
+		// ACT
+		_, err := sut.Use(sut)
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		wanted := Adapter(recording)
+		got := sut.adapter.current()
+		if wanted != got {
+			t.Errorf("wanted %#v, got %#v", wanted, got)
+		}
+	})
+}
+
+func TestSwappableLoggerUseCycle(t *testing.T) {
+	testcases := []struct {
+		name    string
+		arrange func(a, b, c *SwappableLogger)
+		log     func(a, b, c *SwappableLogger) Logger
+	}{
+		{name: "direct",
+			arrange: func(a, b, c *SwappableLogger) { _, _ = b.Use(a) },
+			log:     func(a, b, c *SwappableLogger) Logger { return b },
+		},
+		{name: "indirect",
+			arrange: func(a, b, c *SwappableLogger) { _, _ = b.Use(a); _, _ = c.Use(b) },
+			log:     func(a, b, c *SwappableLogger) Logger { return c },
+		},
+		{name: "through bootstrap",
+			arrange: func(a, b, c *SwappableLogger) {},
+			log: func(a, b, c *SwappableLogger) Logger {
+				bootstrap := NewBootstrapLogger(0)
+				bootstrap.Configure(a.adapter)
+				return bootstrap
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			recording := newRecordingAdapter()
+			a, b, c := NewSwappableLogger(recording), NewSwappableLogger(nil), NewSwappableLogger(nil)
+			tc.arrange(a, b, c)
+
+			// ACT
+			_, err := a.Use(tc.log(a, b, c))
+			a.NewEntry().Info("entry")
+
+			// ASSERT
+			if err == nil {
+				t.Error("wanted error, got nil")
+			}
+			if wanted, got := 1, len(*recording.entries); wanted != got {
+				t.Errorf("wanted %d entries, got %d", wanted, got)
+			}
+		})
+	}
+}
+
+func TestSwappableLoggerSwapCycle(t *testing.T) {
+	// ARRANGE
+	recording := newRecordingAdapter()
+	a, b := NewSwappableLogger(recording), NewSwappableLogger(nil)
+	_, _ = b.Use(a)
+
+	// ACT
+	replaced := a.Swap(b.adapter)
+
+	// ASSERT
+	wanted := Adapter(recording)
+	if got := replaced; wanted != got {
+		t.Errorf("replaced: wanted %#v, got %#v", wanted, got)
+	}
+	if got := a.adapter.current(); wanted != got {
+		t.Errorf("current: wanted %#v, got %#v", wanted, got)
+	}
+}
+
+func TestSwappableLoggerConcurrentUse(t *testing.T) {
+	// ARRANGE
+	sut := NewSwappableLogger(nil)
+	entry := sut.NewEntry()
+	wg := sync.WaitGroup{}
+
+	// ACT
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			sut.Swap(&nulAdapter{})
+		}()
+		go func() {
+			defer wg.Done()
+			entry.Info("entry")
+		}()
+	}
+	wg.Wait()
+}
+
+// blockingAdapter is an Adapter blocking in Emit until released, recording
+// any entry emitted after it is closed.
+type blockingAdapter struct {
+	entered  chan struct{}
+	release  chan struct{}
+	closed   int32
+	inClosed int32
+}
+
+func (a *blockingAdapter) Emit(Level, string) {
+	close(a.entered)
+	<-a.release
+	if atomic.LoadInt32(&a.closed) == 1 {
+		atomic.StoreInt32(&a.inClosed, 1)
+	}
+}
+
+func (a *blockingAdapter) NewEntry() Adapter                    { return a }
+func (a *blockingAdapter) WithField(name string, v any) Adapter { return a }
+
+func (a *blockingAdapter) Close() error {
+	atomic.StoreInt32(&a.closed, 1)
+	return nil
+}
+
+func TestSwappableLoggerSwapWaitsForEmit(t *testing.T) {
+	// ARRANGE
+	adapter := &blockingAdapter{entered: make(chan struct{}), release: make(chan struct{})}
+	sut := NewSwappableLogger(adapter)
+
+	go sut.NewEntry().Info("entry")
+	<-adapter.entered
+
+	// ACT
+	swapped := make(chan struct{})
+	go func() {
+		defer close(swapped)
+		closeAdapter(sut.Swap(nil))
+	}()
+
+	// ASSERT
+	select {
+	case <-swapped:
+		t.Fatal("Swap returned while an entry was being emitted")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(adapter.release)
+	<-swapped
+	if atomic.LoadInt32(&adapter.inClosed) == 1 {
+		t.Error("entry emitted after adapter was closed")
+	}
+}
@@ -0,0 +1,69 @@
+package unilog
+
+import "time"
+
+// TimedOption is an option for configuring the entry emitted on completion
+// of an operation timed using `Timed()`.
+type TimedOption func(*timedOptions)
+
+// timedOptions holds the options for a timed operation.
+type timedOptions struct {
+	level     Level
+	warnAfter time.Duration
+}
+
+// TimedLevel specifies the level of the entry emitted on successful completion
+// of a timed operation (within any `WarnAfter()` threshold).  The default
+// level is `Info`.
+func TimedLevel(level Level) TimedOption {
+	return func(opts *timedOptions) { opts.level = level }
+}
+
+// WarnAfter specifies a duration after which the completion of a timed
+// operation is emitted as a `Warn` level entry.  By default, no threshold
+// is applied.
+func WarnAfter(d time.Duration) TimedOption {
+	return func(opts *timedOptions) { opts.warnAfter = d }
+}
+
+// Timed returns a func to be called on completion of an operation, which
+// emits an entry with a specified message and a "duration" field (the time
+// elapsed since Timed was called).  The func accepts a pointer to an error,
+// allowing it to be deferred with a named error result of the function
+// performing the operation:
+//
+//	func query(ctx context.Context) (err error) {
+//		defer log.WithContext(ctx).Timed("query", unilog.WarnAfter(time.Second))(&err)
+//		...
+//	}
+//
+// The entry is emitted at `Info` level (or as specified by `TimedLevel()`),
+// escalated to `Warn` if the duration exceeds any `WarnAfter()` threshold.
+// If the error is not nil, the entry is emitted at `Error` level with the
+// error added as an "error" field and any context in the error applied (see
+// `errorcontext`).
+func (log *logger) Timed(msg string, opts ...TimedOption) func(*error) {
+	options := timedOptions{level: Info}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	start := time.Now()
+
+	return func(errp *error) {
+		d := time.Since(start)
+
+		if errp != nil && *errp != nil {
+			err := *errp
+			log.withFields([]Field{Duration("duration", d), Err(err)}).
+				entryFromArgs(err).
+				Emit(Error, msg)
+			return
+		}
+
+		level := options.level
+		if options.warnAfter > 0 && d > options.warnAfter && !level.enabledAt(Warn) {
+			level = Warn
+		}
+		log.withFields([]Field{Duration("duration", d)}).Emit(level, msg)
+	}
+}
@@ -0,0 +1,91 @@
+package unilog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blugnu/errorcontext"
+)
+
+func TestTimed(t *testing.T) {
+	type key int
+	oef := enrichmentFuncs
+	defer func() { enrichmentFuncs = oef }()
+	RegisterEnrichment(func(ctx context.Context, e Enricher) Entry {
+		if v := ctx.Value(key(1)); v != nil {
+			return e.WithField("enriched", v)
+		}
+		return e.(Entry)
+	})
+
+	ctxerr := errorcontext.Wrap(context.WithValue(context.Background(), key(1), "value"), errors.New("failed"))
+
+	testcases := []struct {
+		name     string
+		opts     []TimedOption
+		sleep    time.Duration
+		err      error
+		level    Level
+		enriched bool
+	}{
+		{name: "ok", level: Info},
+		{name: "ok/level", opts: []TimedOption{TimedLevel(Debug)}, level: Debug},
+		{name: "ok/within threshold", opts: []TimedOption{WarnAfter(time.Minute)}, level: Info},
+		{name: "ok/over threshold", opts: []TimedOption{WarnAfter(time.Millisecond)}, sleep: 5 * time.Millisecond, level: Warn},
+		{name: "ok/over threshold, error level", opts: []TimedOption{TimedLevel(Error), WarnAfter(time.Millisecond)}, sleep: 5 * time.Millisecond, level: Error},
+		{name: "error", err: errors.New("failed"), level: Error},
+		{name: "error/with context", err: ctxerr, level: Error, enriched: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			adapter := newRecordingAdapter()
+			sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+			// ACT
+			done := sut.Timed("operation", tc.opts...)
+			time.Sleep(tc.sleep)
+			err := tc.err
+			done(&err)
+
+			// ASSERT
+			entry := adapter.last()
+			if entry.level != tc.level || entry.s != "operation" {
+				t.Errorf("wanted %v: operation, got %v: %s", tc.level, entry.level, entry.s)
+			}
+
+			d, ok := entry.fields["duration"].(time.Duration)
+			if !ok || d < tc.sleep {
+				t.Errorf("wanted duration >= %v, got %#v", tc.sleep, entry.fields["duration"])
+			}
+
+			if tc.err != nil && entry.fields["error"] != tc.err {
+				t.Errorf("wanted error %v, got %v", tc.err, entry.fields["error"])
+			}
+
+			wanted := tc.enriched
+			_, got := entry.fields["enriched"]
+			if wanted != got {
+				t.Errorf("enriched: wanted %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestTimedWithNilErrorPointer(t *testing.T) {
+	// ARRANGE
+	adapter := newRecordingAdapter()
+	sut := UsingAdapter(context.Background(), adapter).NewEntry()
+
+	// ACT
+	sut.Timed("operation")(nil)
+
+	// ASSERT
+	wanted := Info
+	got := adapter.last().level
+	if wanted != got {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+}
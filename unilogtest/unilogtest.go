@@ -0,0 +1,181 @@
+// Package unilogtest provides a unilog adapter routing log entries via the
+// `Log` function of a `testing.TB`, and helpers for testing code that
+// emits log entries.
+//
+// It is provided as a separate package so that importing unilog does not
+// link the testing package (and register its flags) in applications.
+package unilogtest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blugnu/unilog"
+)
+
+// Option is a func that configures a Logger returned by `Logger()`.
+type Option func(*testingState)
+
+// FailOnError configures a `Logger()` to fail the test when an entry at
+// `Error` level (or any more severe level, e.g. `Panic` or a custom level
+// more severe than `Error`) is emitted.  `Fatal` entries always fail the
+// test.
+func FailOnError() Option {
+	return func(s *testingState) {
+		s.failOnError = true
+	}
+}
+
+// testingState holds state shared by all entries initialised from
+// a `Logger()`.
+type testingState struct {
+	t           testing.TB
+	done        int32 // set (atomically) to 1 when the test has completed
+	failOnError bool
+}
+
+// testingAdapter is an Adapter that emits log entries via the `Log`
+// function of a `testing.TB`.
+type testingAdapter struct {
+	*testingState
+	fields map[string]any
+}
+
+// Logger returns a Logger that routes log entries via `t.Log()` so that
+// any logs produced by code under test are reported alongside the test
+// results.
+//
+// A `Fatal` entry fails the test using `t.FailNow()`; `unilog.ExitFn` is not
+// called.
+// By default `Error` entries do not fail a test; use the `FailOnError()`
+// option to change this.
+//
+// Entries emitted after the test has completed are discarded.
+func Logger(t testing.TB, opts ...Option) unilog.Logger {
+	state := &testingState{t: t}
+	for _, opt := range opts {
+		opt(state)
+	}
+	t.Cleanup(func() { atomic.StoreInt32(&state.done, 1) })
+
+	return unilog.UsingAdapter(context.Background(), &testingAdapter{state, map[string]any{}}).
+		WithExitBehaviour(state.failNow)
+}
+
+// failNow is the exit behaviour of a `Logger()`, failing the test
+// using `t.FailNow()` which terminates the calling goroutine.  If the test
+// has already completed the calling goroutine is still terminated, ensuring
+// that a `Fatal` entry never returns to the caller.
+func (s *testingState) failNow(int, string) {
+	if atomic.LoadInt32(&s.done) == 1 {
+		runtime.Goexit()
+	}
+	s.t.FailNow()
+}
+
+// completed returns true if the test has completed.
+func (a *testingAdapter) completed() bool {
+	return atomic.LoadInt32(&a.done) == 1
+}
+
+// Emit logs a specified string with any fields via the `t.Log()` function
+// of the test.  If the test has already completed the entry is discarded.
+func (a *testingAdapter) Emit(level unilog.Level, s string) {
+	if a.completed() {
+		return
+	}
+
+	// the test may complete between the check above and logging
+	// the entry, causing t.Log() (or t.Fail()) to panic
+	defer func() { _ = recover() }()
+
+	a.t.Helper()
+	a.t.Log(fieldData(a.fields) + levelPrefix(level) + ": " + s)
+
+	if a.failOnError && level.AtLeast(unilog.Error) {
+		a.t.Fail()
+	}
+}
+
+// NewEntry returns a new adapter with a copy of the fields of the receiver.
+func (a *testingAdapter) NewEntry() unilog.Adapter {
+	fields := make(map[string]any, len(a.fields))
+	for k, v := range a.fields {
+		fields[k] = v
+	}
+	return &testingAdapter{a.testingState, fields}
+}
+
+// WithField returns a new adapter with the named value added to a copy of
+// the fields of the receiver.
+func (a *testingAdapter) WithField(name string, value any) unilog.Adapter {
+	entry := a.NewEntry().(*testingAdapter)
+	entry.fields[name] = value
+	return entry
+}
+
+// AssertFatal calls a func with a `Logger()`, failing the test if
+// the func does not emit a `Fatal` entry with the specified message.
+//
+// The Logger supplied to the func panics on `Fatal` (see `unilog.PanicOnFatal()`)
+// so any code following the `Fatal` entry in the func is not executed.
+func AssertFatal(t testing.TB, msg string, fn func(unilog.Logger)) {
+	t.Helper()
+
+	defer func() {
+		t.Helper()
+		r := recover()
+		if r == nil {
+			t.Errorf("wanted Fatal %q, got no Fatal entry", msg)
+			return
+		}
+		p, ok := r.(unilog.FatalPanic)
+		if !ok {
+			panic(r)
+		}
+		if p.Message != msg {
+			t.Errorf("\nwanted Fatal %q\ngot    Fatal %q", msg, p.Message)
+		}
+	}()
+
+	fn(Logger(t).WithExitBehaviour(unilog.PanicOnFatal()))
+}
+
+// levelPrefix returns the prefix for entries at a specified level; the name
+// of the level, in uppercase.
+func levelPrefix(level unilog.Level) string {
+	if text, err := level.MarshalText(); err == nil {
+		return strings.ToUpper(string(text))
+	}
+	return strings.ToUpper(level.String())
+}
+
+// fieldData returns the fields of an entry, sorted by name, as a string of
+// name=value pairs, each followed by a space.  Names and values containing
+// spaces are quoted.
+func fieldData(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := ""
+	for _, k := range keys {
+		vs := fmt.Sprintf("%v", fields[k])
+		if strings.Contains(k, " ") {
+			k = strconv.Quote(k)
+		}
+		if strings.Contains(vs, " ") {
+			vs = strconv.Quote(vs)
+		}
+		data = data + k + "=" + vs + " "
+	}
+	return data
+}
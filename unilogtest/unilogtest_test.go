@@ -0,0 +1,222 @@
+package unilogtest
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/blugnu/unilog"
+)
+
+// critical is a custom level more severe than Error; levels remain registered
+// for the life of the process, so an existing registration is reused (e.g.
+// when tests are run with -count).
+var critical = func() unilog.Level {
+	level, err := unilog.RegisterLevel("Critical", unilog.Error)
+	if err != nil {
+		level, _ = unilog.ParseLevel("Critical")
+	}
+	return level
+}()
+
+// mockTB is a testing.TB recording calls to the functions used by
+// the testing adapter.  Functions not overridden are delegated to the
+// (embedded) testing.TB of the test using the mock.
+type mockTB struct {
+	testing.TB
+	logs      []string
+	failed    bool
+	failedNow bool
+	cleanups  []func()
+	errors    []string
+}
+
+func (mock *mockTB) Helper()           {}
+func (mock *mockTB) Log(args ...any)   { mock.logs = append(mock.logs, args[0].(string)) }
+func (mock *mockTB) Fail()             { mock.failed = true }
+func (mock *mockTB) FailNow()          { mock.failed = true; mock.failedNow = true; runtime.Goexit() }
+func (mock *mockTB) Cleanup(fn func()) { mock.cleanups = append(mock.cleanups, fn) }
+func (mock *mockTB) Errorf(format string, args ...any) {
+	mock.errors = append(mock.errors, fmt.Sprintf(format, args...))
+}
+
+// complete simulates completion of the test by calling any cleanup funcs.
+func (mock *mockTB) complete() {
+	for _, fn := range mock.cleanups {
+		fn()
+	}
+}
+
+// runInGoroutine runs a func in a new goroutine, waiting for it to
+// complete (or exit).
+func runInGoroutine(fn func()) {
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fn()
+	}()
+	wg.Wait()
+}
+
+func TestLogger(t *testing.T) {
+	// ARRANGE
+	ofn := unilog.ExitFn
+	defer func() { unilog.ExitFn = ofn }()
+	exitCalled := false
+	unilog.ExitFn = func(int) { exitCalled = true }
+
+	testcases := []struct {
+		name      string
+		opts      []Option
+		fn        func(unilog.Entry)
+		output    string
+		failed    bool
+		failedNow bool
+	}{
+		{name: "info", fn: func(e unilog.Entry) { e.Info("entry text") }, output: "INFO: entry text"},
+		{name: "with fields", fn: func(e unilog.Entry) { e.WithField("key", "value").Warn("entry text") }, output: "key=value WARN: entry text"},
+		{name: "error", fn: func(e unilog.Entry) { e.Error("entry text") }, output: "ERROR: entry text"},
+		{name: "error, fail on error", opts: []Option{FailOnError()}, fn: func(e unilog.Entry) { e.Error("entry text") }, output: "ERROR: entry text", failed: true},
+		{name: "critical", fn: func(e unilog.Entry) { e.Emit(critical, "entry text") }, output: "CRITICAL: entry text"},
+		{name: "critical, fail on error", opts: []Option{FailOnError()}, fn: func(e unilog.Entry) { e.Emit(critical, "entry text") }, output: "CRITICAL: entry text", failed: true},
+		{name: "panic, fail on error", opts: []Option{FailOnError()}, fn: func(e unilog.Entry) {
+			defer func() { _ = recover() }()
+			e.Panic("entry text")
+		}, output: "PANIC: entry text", failed: true},
+		{name: "fatal", fn: func(e unilog.Entry) { e.Fatal("entry text") }, output: "FATAL: entry text", failed: true, failedNow: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			exitCalled = false
+			mock := &mockTB{TB: t}
+			sut := Logger(mock, tc.opts...)
+
+			// ACT
+			runInGoroutine(func() { tc.fn(sut.NewEntry()) })
+
+			// ASSERT
+			t.Run("output", func(t *testing.T) {
+				wanted := tc.output
+				got := mock.logs[len(mock.logs)-1]
+				if wanted != got {
+					t.Errorf("\nwanted %q\ngot    %q", wanted, got)
+				}
+			})
+
+			t.Run("fails test", func(t *testing.T) {
+				wanted := tc.failed
+				got := mock.failed
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			t.Run("fails test now", func(t *testing.T) {
+				wanted := tc.failedNow
+				got := mock.failedNow
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+
+			t.Run("calls exit fn", func(t *testing.T) {
+				wanted := false
+				got := exitCalled
+				if wanted != got {
+					t.Errorf("wanted %v, got %v", wanted, got)
+				}
+			})
+		})
+	}
+}
+
+func TestLoggerAfterTestCompleted(t *testing.T) {
+	// ARRANGE
+	ofn := unilog.ExitFn
+	defer func() { unilog.ExitFn = ofn }()
+	exitCalled := false
+	unilog.ExitFn = func(int) { exitCalled = true }
+
+	mock := &mockTB{TB: t}
+	sut := Logger(mock)
+	mock.complete()
+
+	// ACT
+	runInGoroutine(func() {
+		sut.NewEntry().Info("entry text")
+		sut.NewEntry().Fatal("entry text")
+	})
+
+	// ASSERT
+	t.Run("discards entries", func(t *testing.T) {
+		wanted := 0
+		got := len(mock.logs)
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("does not fail test", func(t *testing.T) {
+		wanted := false
+		got := mock.failed
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("calls exit fn", func(t *testing.T) {
+		wanted := false
+		got := exitCalled
+		if wanted != got {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+}
+
+func TestAssertFatal(t *testing.T) {
+	testcases := []struct {
+		name   string
+		fn     func(unilog.Logger)
+		errors int
+	}{
+		{name: "fatal with expected message", fn: func(log unilog.Logger) { log.NewEntry().Fatal("expected") }},
+		{name: "fatalf with expected message", fn: func(log unilog.Logger) { log.NewEntry().Fatalf("%s", "expected") }},
+		{name: "fatal with other message", fn: func(log unilog.Logger) { log.NewEntry().Fatal("other") }, errors: 1},
+		{name: "no fatal", fn: func(log unilog.Logger) { log.NewEntry().Error("expected") }, errors: 1},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			mock := &mockTB{TB: t}
+
+			// ACT
+			AssertFatal(mock, "expected", tc.fn)
+
+			// ASSERT
+			wanted := tc.errors
+			got := len(mock.errors)
+			if wanted != got {
+				t.Errorf("wanted %v, got %v (%q)", wanted, got, mock.errors)
+			}
+		})
+	}
+
+	t.Run("other panic", func(t *testing.T) {
+		// ARRANGE
+		mock := &mockTB{TB: t}
+		defer func() {
+			// ASSERT
+			wanted := "other panic"
+			got := recover()
+			if wanted != got {
+				t.Errorf("wanted %v, got %v", wanted, got)
+			}
+		}()
+
+		// ACT
+		AssertFatal(mock, "expected", func(unilog.Logger) { panic("other panic") })
+	})
+}